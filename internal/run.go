@@ -19,19 +19,6 @@ type RunCmdOptions struct {
 	Prerelease          bool
 }
 
-func removeReleaseStage(stages []string) []string {
-	stageIndex := -1
-	for i, stage := range stages {
-		if stage == engine.GetStage(engine.ReleaseStage) {
-			stageIndex = i
-		}
-	}
-	if stageIndex >= 0 {
-		stages = append(stages[:stageIndex], stages[stageIndex+1:]...)
-	}
-	return stages
-}
-
 func RunCmd(options *RunCmdOptions) error {
 	var planData map[string]interface{}
 	if _, err := os.Stat(options.Plan); os.IsNotExist(err) {
@@ -67,15 +54,13 @@ func RunCmd(options *RunCmdOptions) error {
 	if err != nil {
 		return fmt.Errorf("failed connecting to the dagger agent")
 	}
-	if options.Prerelease {
-		removeReleaseStage(options.Stages)
-	}
 	if err := engine.Run(engine.RunArgs{
 		Source:              options.Source,
 		Spec:                string(spec),
 		Client:              client,
 		Stages:              options.Stages,
 		IgnoreMissingInputs: options.IgnoreMissingInputs,
+		Prerelease:          options.Prerelease,
 	}); err != nil {
 		log.Error("", "err", err)
 		os.Exit(1)
@@ -5,7 +5,6 @@ import (
 	"path/filepath"
 	"testing"
 
-	"github.com/stretchr/testify/assert"
 	_ "github.com/trustacks/trustacks/pkg/actions"
 	"github.com/trustacks/trustacks/pkg/engine"
 )
@@ -34,17 +33,6 @@ func makeTestdata(t *testing.T) (string, func()) {
 	return d, func() { os.RemoveAll(d) }
 }
 
-func TestRemoveReleaseStages(t *testing.T) {
-	stages := []string{
-		engine.GetStage(engine.CommitStage),
-		engine.GetStage(engine.DeployStage),
-		engine.GetStage(engine.ReleaseStage),
-	}
-	assert.Contains(t, stages, engine.GetStage(engine.ReleaseStage))
-	stages = removeReleaseStage(stages)
-	assert.NotContains(t, stages, engine.GetStage(engine.ReleaseStage))
-}
-
 func TestRunCmdFromPlanIntegration(t *testing.T) {
 	d, clean := makeTestdata(t)
 	defer clean()
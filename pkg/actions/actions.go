@@ -3,16 +3,29 @@ package actions
 import (
 	// import actions
 	_ "github.com/trustacks/trustacks/pkg/actions/argocd"
+	_ "github.com/trustacks/trustacks/pkg/actions/bandit"
+	_ "github.com/trustacks/trustacks/pkg/actions/compose"
 	_ "github.com/trustacks/trustacks/pkg/actions/container"
+	_ "github.com/trustacks/trustacks/pkg/actions/cypress"
+	_ "github.com/trustacks/trustacks/pkg/actions/dotnet"
 	_ "github.com/trustacks/trustacks/pkg/actions/eslint"
+	_ "github.com/trustacks/trustacks/pkg/actions/flake8"
 	_ "github.com/trustacks/trustacks/pkg/actions/golang"
 	_ "github.com/trustacks/trustacks/pkg/actions/golangcilint"
 	_ "github.com/trustacks/trustacks/pkg/actions/goreleaser"
+	_ "github.com/trustacks/trustacks/pkg/actions/govulncheck"
+	_ "github.com/trustacks/trustacks/pkg/actions/java"
 	_ "github.com/trustacks/trustacks/pkg/actions/javascript"
+	_ "github.com/trustacks/trustacks/pkg/actions/mypy"
 	_ "github.com/trustacks/trustacks/pkg/actions/npm"
+	_ "github.com/trustacks/trustacks/pkg/actions/php"
+	_ "github.com/trustacks/trustacks/pkg/actions/playwright"
 	_ "github.com/trustacks/trustacks/pkg/actions/pytest"
 	_ "github.com/trustacks/trustacks/pkg/actions/python"
+	_ "github.com/trustacks/trustacks/pkg/actions/ruby"
+	_ "github.com/trustacks/trustacks/pkg/actions/rust"
 	_ "github.com/trustacks/trustacks/pkg/actions/sonarqube"
 	_ "github.com/trustacks/trustacks/pkg/actions/tox"
 	_ "github.com/trustacks/trustacks/pkg/actions/trivy"
+	_ "github.com/trustacks/trustacks/pkg/actions/typescript"
 )
@@ -0,0 +1,51 @@
+package bandit
+
+import (
+	"context"
+	"strings"
+
+	"dagger.io/dagger"
+	"github.com/trustacks/trustacks/pkg/actions/python"
+	"github.com/trustacks/trustacks/pkg/engine"
+)
+
+var banditRunAction = &engine.Action{
+	Name:        "banditRun",
+	DisplayName: "Bandit Run",
+	Description: "Scan the python source for security issues with bandit.",
+	Image:       python.Image,
+	Stage:       engine.NonFunctionalStage,
+	Caches:      python.Caches,
+	Script: func(container *dagger.Container, _ map[string]interface{}, utils *engine.ActionUtilities) error {
+		config := utils.GetConfig()
+		container = container.WithExec([]string{"apt", "update"})
+		container = container.WithExec([]string{"apt", "install", "gcc", "-y"})
+		container = container.WithExec([]string{"apt", "install", "-y", strings.Join(config.Python.Libraries, " ")})
+		// the source dependencies provide the configured bandit plugins.
+		container, _, err := python.InstallPythonDependencies(container)
+		if err != nil {
+			return err
+		}
+		if config.Python.DevRequirements != "" {
+			container = container.WithExec([]string{"pip", "install", "-r", config.Python.DevRequirements})
+		}
+		entries, err := container.Directory("/src").Entries(context.Background())
+		if err != nil {
+			return err
+		}
+		args, _, err := banditConfigArgs(entries, engine.ContainerFileReader(container))
+		if err != nil {
+			return err
+		}
+		container = container.
+			WithExec([]string{"pip", "install", "bandit[toml]"}).
+			WithExec(append([]string{"bandit", "-r", "."}, args...))
+		_, err = container.Sync(context.Background())
+		return err
+	},
+	AdmissionCriteria: []engine.Fact{BanditConfigExistsFact},
+}
+
+func init() {
+	engine.RegisterAction(banditRunAction)
+}
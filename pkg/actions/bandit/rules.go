@@ -0,0 +1,88 @@
+package bandit
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+
+	"github.com/bigkevmcd/go-configparser"
+	mapset "github.com/deckarep/golang-set/v2"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/trustacks/trustacks/pkg/actions/python"
+	"github.com/trustacks/trustacks/pkg/engine"
+)
+
+var (
+	// BanditConfigExistsFact is true if bandit is configured in the
+	// pyproject.toml, a bandit.yaml or the .bandit file.
+	BanditConfigExistsFact = engine.NewFact()
+)
+
+// banditConfigArgs returns the bandit arguments that load the bandit
+// configuration of the source.
+func banditConfigArgs(entries []string, readFile engine.FileReader) ([]string, bool, error) {
+	for _, name := range []string{"bandit.yaml", "bandit.yml"} {
+		if engine.HasEntry(entries, name) {
+			return []string{"-c", name}, true, nil
+		}
+	}
+	if engine.HasEntry(entries, "pyproject.toml") {
+		data, err := readFile("pyproject.toml")
+		if err != nil {
+			return nil, false, err
+		}
+		project := struct {
+			Tool struct {
+				Bandit map[string]interface{} `toml:"bandit"`
+			} `toml:"tool"`
+		}{}
+		if err := toml.Unmarshal(data, &project); err != nil {
+			return nil, false, err
+		}
+		if project.Tool.Bandit != nil {
+			return []string{"-c", "pyproject.toml"}, true, nil
+		}
+	}
+	if engine.HasEntry(entries, ".bandit") {
+		data, err := readFile(".bandit")
+		if err != nil {
+			return nil, false, err
+		}
+		cfg, err := configparser.ParseReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, false, err
+		}
+		if cfg.HasSection("bandit") {
+			return []string{"--ini", ".bandit"}, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+// BanditConfigExistsRule checks if bandit is configured in the source
+// root.
+var BanditConfigExistsRule engine.Rule = func(source string, _ engine.Collector, _ mapset.Set[engine.Fact]) (engine.Fact, error) {
+	var fact = engine.NilFact
+	dirEntries, err := os.ReadDir(source)
+	if err != nil {
+		return fact, err
+	}
+	entries := []string{}
+	for _, entry := range dirEntries {
+		entries = append(entries, entry.Name())
+	}
+	_, ok, err := banditConfigArgs(entries, func(name string) ([]byte, error) {
+		return os.ReadFile(filepath.Join(source, name))
+	})
+	if err != nil {
+		return fact, err
+	}
+	if ok {
+		fact = BanditConfigExistsFact
+	}
+	return fact, nil
+}
+
+func init() {
+	engine.AddToRuleset(&python.PackageManagerRule, &BanditConfigExistsRule)
+}
@@ -0,0 +1,62 @@
+package bandit
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBanditConfigExistsRule(t *testing.T) {
+	t.Run("BanditConfigExistsFact is true", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			contents string
+			args     []string
+		}{
+			{"bandit.yaml", "skips: [B101]\n", []string{"-c", "bandit.yaml"}},
+			{"bandit.yml", "skips: [B101]\n", []string{"-c", "bandit.yml"}},
+			{"pyproject.toml", "[tool.bandit]\nskips = [\"B101\"]\n", []string{"-c", "pyproject.toml"}},
+			{".bandit", "[bandit]\nskips = B101\n", []string{"--ini", ".bandit"}},
+		}
+		for _, tc := range tests {
+			d, err := os.MkdirTemp("", "test-src")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(d)
+			if err := os.WriteFile(filepath.Join(d, tc.name), []byte(tc.contents), 0744); err != nil {
+				t.Fatal(err)
+			}
+			fact, err := BanditConfigExistsRule(d, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, fact, BanditConfigExistsFact, tc.name)
+			args, _, err := banditConfigArgs([]string{tc.name}, func(name string) ([]byte, error) {
+				return os.ReadFile(filepath.Join(d, name))
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, tc.args, args)
+		}
+	})
+
+	t.Run("BanditConfigExistsFact is false", func(t *testing.T) {
+		d, err := os.MkdirTemp("", "test-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(d)
+		if err := os.WriteFile(filepath.Join(d, "pyproject.toml"), []byte("[tool.black]\n"), 0744); err != nil {
+			t.Fatal(err)
+		}
+		fact, err := BanditConfigExistsRule(d, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.NotEqual(t, fact, BanditConfigExistsFact)
+	})
+}
@@ -0,0 +1,86 @@
+package compose
+
+import (
+	"context"
+	"fmt"
+
+	"dagger.io/dagger"
+	"github.com/trustacks/trustacks/pkg/engine"
+)
+
+var composeTestAction = &engine.Action{
+	Name:        "composeTest",
+	DisplayName: "Compose Test",
+	Description: "Run the acceptance tests against the container image in a docker compose stack.",
+	Image:       func(_ *engine.Config) string { return "docker:24.0.7-cli" },
+	Stage:       engine.AcceptanceStage,
+	InputArtifacts: []engine.Artifact{
+		engine.ContainerImageArtifact,
+	},
+	Script: func(container *dagger.Container, _ map[string]interface{}, utils *engine.ActionUtilities) error {
+		config := utils.GetConfig()
+		entries, err := container.Directory("/src").Entries(context.Background())
+		if err != nil {
+			return err
+		}
+		file := composeFile(config, entries)
+		data, err := container.File(file).Contents(context.Background())
+		if err != nil {
+			return err
+		}
+		services, err := appServices([]byte(data))
+		if err != nil {
+			return err
+		}
+		if len(services) == 0 {
+			return fmt.Errorf("'%s' does not have a service that uses the ${%s} image", file, appImageVariable)
+		}
+		service := config.Compose.Service
+		if service == "" {
+			service = services[0]
+		}
+		container, stop, err := utils.WithDockerdService(container)
+		if err != nil {
+			return err
+		}
+		defer stop()
+		// load the images into the docker daemon and tag them for the
+		// compose file.
+		type tarball struct{ name, path string }
+		tarballs := []tarball{}
+		images := utils.Images(engine.ContainerImageArtifact)
+		if images == nil {
+			var imageMount *engine.ArtifactMount
+			container, imageMount, err = utils.MountImage(container, engine.ContainerImageArtifact)
+			if err != nil {
+				return err
+			}
+			tarballs = append(tarballs, tarball{"", imageMount.Path("image.tar")})
+		}
+		for i, image := range images {
+			path := fmt.Sprintf("/tmp/images/%d.tar", i)
+			container = container.WithFile(path, image.Variants[0].AsTarball())
+			tarballs = append(tarballs, tarball{image.Name, path})
+		}
+		unnamed := false
+		for _, t := range tarballs {
+			unnamed = unnamed || t.name == ""
+			container = container.
+				WithExec([]string{"/bin/sh", "-c", fmt.Sprintf(`id=$(docker load -q -i %s | sed -E 's/^Loaded image( ID)?: //') && docker tag "$id" %s`, t.path, imageTag(t.name))}).
+				WithEnvVariable(imageVariable(t.name), imageTag(t.name))
+		}
+		if !unnamed {
+			// the first image is available as APP_IMAGE if there is no
+			// unnamed image.
+			container = container.WithEnvVariable(imageVariable(""), imageTag(tarballs[0].name))
+		}
+		container = container.WithExec([]string{"/bin/sh", "-c", testScript(file, service, config.Compose.Command)})
+		_, err = container.Sync(context.Background())
+		return err
+	},
+	AdmissionCriteria: []engine.Fact{ComposeAppServiceExistsFact, ComposeTestCommandExistsFact},
+}
+
+func init() {
+	engine.RegisterAction(composeTestAction)
+}
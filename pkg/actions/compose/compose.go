@@ -0,0 +1,91 @@
+package compose
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/trustacks/trustacks/pkg/engine"
+	"gopkg.in/yaml.v2"
+)
+
+// appImageVariable is the variable that compose files use to reference
+// the application image (ie. image: ${APP_IMAGE}).
+const appImageVariable = "APP_IMAGE"
+
+// composeFiles are the default compose file names in order of
+// precedence.
+var composeFiles = []string{"compose.yaml", "compose.yml", "docker-compose.yaml", "docker-compose.yml"}
+
+var (
+	appImagePattern    = regexp.MustCompile(`\$\{?` + appImageVariable + `(_[A-Z0-9_]+)?\b`)
+	invalidNamePattern = regexp.MustCompile(`[^A-Za-z0-9]`)
+)
+
+// composeFile returns the configured compose file or the first default
+// compose file in the entries.
+func composeFile(config *engine.Config, entries []string) string {
+	if config.Compose.File != "" {
+		return config.Compose.File
+	}
+	for _, name := range composeFiles {
+		if engine.HasEntry(entries, name) {
+			return name
+		}
+	}
+	return ""
+}
+
+// appServices returns the sorted names of the compose services that use
+// the application image.
+func appServices(data []byte) ([]string, error) {
+	spec := struct {
+		Services map[string]struct {
+			Image string `yaml:"image"`
+		} `yaml:"services"`
+	}{}
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return nil, err
+	}
+	services := []string{}
+	for name, service := range spec.Services {
+		if appImagePattern.MatchString(service.Image) {
+			services = append(services, name)
+		}
+	}
+	sort.Strings(services)
+	return services, nil
+}
+
+// imageVariable returns the compose variable of the image. The first
+// image is also available as APP_IMAGE.
+func imageVariable(name string) string {
+	if name == "" {
+		return appImageVariable
+	}
+	return fmt.Sprintf("%s_%s", appImageVariable, strings.ToUpper(invalidNamePattern.ReplaceAllString(name, "_")))
+}
+
+// imageTag returns the local tag of the loaded image.
+func imageTag(name string) string {
+	if name == "" {
+		return "trustacks-app:acceptance"
+	}
+	return fmt.Sprintf("trustacks-app-%s:acceptance", strings.ToLower(name))
+}
+
+// testScript returns the script that starts the compose stack, runs the
+// test command and always tears the stack down.
+func testScript(file, service, command string) string {
+	compose := fmt.Sprintf("docker compose -f %s", shellQuote(file))
+	return fmt.Sprintf(
+		"%[1]s up -d --wait && %[1]s run --rm %[2]s sh -c %[3]s; code=$?; %[1]s down -v --remove-orphans; exit $code",
+		compose, shellQuote(service), shellQuote(command),
+	)
+}
+
+// shellQuote single quotes the value for the shell.
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'"'"'`) + "'"
+}
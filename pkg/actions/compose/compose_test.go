@@ -0,0 +1,39 @@
+package compose
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppServices(t *testing.T) {
+	services, err := appServices([]byte(`services:
+  web:
+    image: ${APP_IMAGE_WEB:-web}
+  api:
+    image: ${APP_IMAGE}
+  db:
+    image: postgres:16
+  other:
+    image: ${APP_IMAGES}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"api", "web"}, services)
+}
+
+func TestImageVariable(t *testing.T) {
+	assert.Equal(t, "APP_IMAGE", imageVariable(""))
+	assert.Equal(t, "APP_IMAGE_MY_API", imageVariable("my-api"))
+	assert.Equal(t, "trustacks-app:acceptance", imageTag(""))
+	assert.Equal(t, "trustacks-app-api:acceptance", imageTag("api"))
+}
+
+func TestTestScript(t *testing.T) {
+	assert.Equal(
+		t,
+		`docker compose -f 'compose.yaml' up -d --wait && docker compose -f 'compose.yaml' run --rm 'app' sh -c 'echo '"'"'ok'"'"''; code=$?; docker compose -f 'compose.yaml' down -v --remove-orphans; exit $code`,
+		testScript("compose.yaml", "app", "echo 'ok'"),
+	)
+}
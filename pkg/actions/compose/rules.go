@@ -0,0 +1,70 @@
+package compose
+
+import (
+	"os"
+	"path/filepath"
+
+	mapset "github.com/deckarep/golang-set/v2"
+	"github.com/trustacks/trustacks/pkg/actions/container"
+	"github.com/trustacks/trustacks/pkg/engine"
+)
+
+var (
+	// ComposeAppServiceExistsFact is true if a compose file in the
+	// source has a service that runs the application image.
+	ComposeAppServiceExistsFact = engine.NewFact()
+	// ComposeTestCommandExistsFact is true if the compose test command
+	// is configured.
+	ComposeTestCommandExistsFact = engine.NewFact()
+)
+
+var ComposeAppServiceExistsRule engine.Rule = func(source string, _ engine.Collector, _ mapset.Set[engine.Fact]) (engine.Fact, error) {
+	var fact = engine.NilFact
+	config, err := engine.NewSourceConfig(source)
+	if err != nil {
+		return fact, err
+	}
+	dirEntries, err := os.ReadDir(source)
+	if err != nil {
+		return fact, err
+	}
+	entries := []string{}
+	for _, entry := range dirEntries {
+		entries = append(entries, entry.Name())
+	}
+	file := composeFile(config, entries)
+	if file == "" {
+		return fact, nil
+	}
+	data, err := os.ReadFile(filepath.Join(source, file))
+	if os.IsNotExist(err) {
+		return fact, nil
+	} else if err != nil {
+		return fact, err
+	}
+	services, err := appServices(data)
+	if err != nil {
+		return fact, err
+	}
+	if len(services) > 0 {
+		fact = ComposeAppServiceExistsFact
+	}
+	return fact, nil
+}
+
+var ComposeTestCommandExistsRule engine.Rule = func(source string, _ engine.Collector, _ mapset.Set[engine.Fact]) (engine.Fact, error) {
+	var fact = engine.NilFact
+	config, err := engine.NewSourceConfig(source)
+	if err != nil {
+		return fact, err
+	}
+	if config.Compose.Command != "" {
+		fact = ComposeTestCommandExistsFact
+	}
+	return fact, nil
+}
+
+func init() {
+	engine.AddToRuleset(&container.ContainerfileExistsRule, &ComposeAppServiceExistsRule)
+	engine.AddToRuleset(&ComposeAppServiceExistsRule, &ComposeTestCommandExistsRule)
+}
@@ -0,0 +1,108 @@
+package compose
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComposeAppServiceExistsRule(t *testing.T) {
+	t.Run("ComposeAppServiceExistsFact is true", func(t *testing.T) {
+		for _, name := range composeFiles {
+			d, err := os.MkdirTemp("", "test-src")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(d)
+			contents := []byte(`services:
+  app:
+    image: ${APP_IMAGE}
+    depends_on: [db]
+  db:
+    image: postgres:16
+`)
+			if err := os.WriteFile(filepath.Join(d, name), contents, 0644); err != nil {
+				t.Fatal(err)
+			}
+			fact, err := ComposeAppServiceExistsRule(d, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, fact, ComposeAppServiceExistsFact)
+		}
+	})
+
+	t.Run("ComposeAppServiceExistsFact is true for configured compose files", func(t *testing.T) {
+		d, err := os.MkdirTemp("", "test-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(d)
+		if err := os.WriteFile(filepath.Join(d, "trustacks.toml"), []byte("[compose]\nfile = \"test/compose.yaml\"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Mkdir(filepath.Join(d, "test"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(d, "test", "compose.yaml"), []byte("services:\n  api:\n    image: $APP_IMAGE_API\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		fact, err := ComposeAppServiceExistsRule(d, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, fact, ComposeAppServiceExistsFact)
+	})
+
+	t.Run("ComposeAppServiceExistsFact is false", func(t *testing.T) {
+		d, err := os.MkdirTemp("", "test-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(d)
+		if err := os.WriteFile(filepath.Join(d, "compose.yaml"), []byte("services:\n  db:\n    image: postgres:16\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		fact, err := ComposeAppServiceExistsRule(d, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.NotEqual(t, fact, ComposeAppServiceExistsFact)
+	})
+}
+
+func TestComposeTestCommandExistsRule(t *testing.T) {
+	t.Run("ComposeTestCommandExistsFact is true", func(t *testing.T) {
+		d, err := os.MkdirTemp("", "test-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(d)
+		if err := os.WriteFile(filepath.Join(d, "trustacks.toml"), []byte("[compose]\ncommand = \"npm run test:acceptance\"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		fact, err := ComposeTestCommandExistsRule(d, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, fact, ComposeTestCommandExistsFact)
+	})
+
+	t.Run("ComposeTestCommandExistsFact is false", func(t *testing.T) {
+		d, err := os.MkdirTemp("", "test-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(d)
+		if err := os.WriteFile(filepath.Join(d, "trustacks.toml"), []byte("[compose]\nservice = \"tests\"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		fact, err := ComposeTestCommandExistsRule(d, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.NotEqual(t, fact, ComposeTestCommandExistsFact)
+	})
+}
@@ -3,13 +3,26 @@ package container
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"dagger.io/dagger"
 	"github.com/mitchellh/mapstructure"
 	"github.com/trustacks/trustacks/pkg/engine"
 )
 
+// renameBinariesScript renames the platform binaries of the build
+// output (ie. app_linux_arm64 or app_windows_amd64.exe) to the names
+// used in the containerfile (ie. app or app.exe).
+func renameBinariesScript(buildDir, platform string) string {
+	suffix := "_" + engine.PlatformPath(platform)
+	return fmt.Sprintf(
+		`[ -d %[1]s/.build ] || exit 0; cd %[1]s/.build && for f in *%[2]s *%[2]s.exe; do [ -e "$f" ] || continue; case "$f" in *.exe) mv "$f" "${f%%%[2]s.exe}.exe" ;; *) mv "$f" "${f%%%[2]s}" ;; esac; done; true`,
+		buildDir, suffix,
+	)
+}
+
 var containerBuildAction = &engine.Action{
 	Name:        "containerBuild",
 	DisplayName: "Container Build",
@@ -26,19 +39,76 @@ var containerBuildAction = &engine.Action{
 		container, buildMount, err := utils.Mount(container, engine.BuildArtifact)
 		if err != nil && err != engine.ErrArtifactNotFound {
 			return err
-		} else if err == nil {
-			container = container.WithExec([]string{"cp", "-r", buildMount.Path(".build"), "./.build"})
 		}
-		container = container.Directory("/src").DockerBuild()
-		if err := utils.ExportContainer(container, engine.ContainerImageArtifact); err != nil {
+		config := utils.GetConfig()
+		declaredImages, err := sourceImages(config)
+		if err != nil {
 			return err
 		}
-		_, err = container.Sync(context.Background())
-		return err
+		platforms := config.Container.Platforms
+		if len(platforms) == 0 {
+			// build for the engine platform.
+			platforms = []string{""}
+		}
+		images := []engine.Image{}
+		for _, declared := range declaredImages {
+			buildDir := filepath.Join("/src", buildContext(declared))
+			image := engine.Image{Name: declared.Name}
+			for _, platform := range platforms {
+				variant := container
+				if buildMount != nil {
+					// copy the build outputs (ie. .build or dist) into the
+					// build context.
+					variant = variant.WithExec([]string{"cp", "-r", buildMount.Path("") + "/.", buildDir})
+					binaryPlatform := platform
+					if binaryPlatform == "" {
+						// the golang build matrix suffixes the binaries
+						// even when the image is built for the engine
+						// platform.
+						enginePlatform, err := variant.Platform(context.Background())
+						if err != nil {
+							return err
+						}
+						binaryPlatform = string(enginePlatform)
+					}
+					variant = variant.WithExec([]string{"/bin/sh", "-c", renameBinariesScript(buildDir, binaryPlatform)})
+				}
+				opts, err := dockerBuildOpts(declared, platform, utils)
+				if err != nil {
+					return err
+				}
+				variant = variant.Directory(buildDir).DockerBuild(opts)
+				if _, err := variant.Sync(context.Background()); err != nil {
+					return err
+				}
+				image.Variants = append(image.Variants, variant)
+			}
+			images = append(images, image)
+		}
+		return utils.ExportImages(images, engine.ContainerImageArtifact)
 	},
 	AdmissionCriteria: []engine.Fact{ContainerfileHasPredictableDependenciesFact},
 }
 
+// gitRevision returns the short commit sha and the branch name of the
+// source repository. The branch is empty for detached heads.
+func gitRevision(container *dagger.Container) (string, string, error) {
+	container = container.WithExec([]string{"apk", "add", "git"})
+	sha, err := container.WithExec([]string{"git", "-c", "safe.directory=*", "rev-parse", "--short", "HEAD"}).Stdout(context.Background())
+	if err != nil {
+		return "", "", err
+	}
+	branch, err := container.WithExec([]string{"git", "-c", "safe.directory=*", "rev-parse", "--abbrev-ref", "HEAD"}).Stdout(context.Background())
+	if err != nil {
+		return "", "", err
+	}
+	branch = strings.TrimSpace(branch)
+	if branch == "HEAD" {
+		branch = ""
+	}
+	return strings.TrimSpace(sha), branch, nil
+}
+
 var containerPublishAction = &engine.Action{
 	Name:        "containerPublish",
 	DisplayName: "Container Publish",
@@ -51,6 +121,9 @@ var containerPublishAction = &engine.Action{
 	OptionalInputArtifacts: []engine.Artifact{
 		engine.SemanticVersionArtifact,
 	},
+	OutputArtifacts: []engine.Artifact{
+		engine.ImageDigestArtifact,
+	},
 	Script: func(container *dagger.Container, inputs map[string]interface{}, utils *engine.ActionUtilities) error {
 		args := struct {
 			CONTAINER_REGISTRY          string //nolint:revive,stylecheck
@@ -60,35 +133,83 @@ var containerPublishAction = &engine.Action{
 		if err := mapstructure.Decode(inputs, &args); err != nil {
 			return err
 		}
-		container, imageMount, err := utils.MountImage(container, engine.ContainerImageArtifact)
+		config := utils.GetConfig()
+		strategies := config.Container.Tags
+		if len(strategies) == 0 {
+			strategies = []string{VersionTagStrategy}
+		}
+		declaredImages, err := sourceImages(config)
 		if err != nil {
 			return err
 		}
-		version := utils.GetConfig().Common.Version
-		if version == "" {
-			container, versionMount, err := utils.Mount(container, engine.SemanticVersionArtifact)
+		repositories := map[string]string{}
+		for _, declared := range declaredImages {
+			repositories[declared.Name] = imageRepository(args.CONTAINER_REGISTRY, declared)
+		}
+		images := utils.Images(engine.ContainerImageArtifact)
+		if images == nil {
+			var imageMount *engine.ArtifactMount
+			container, imageMount, err = utils.MountImage(container, engine.ContainerImageArtifact)
 			if err != nil {
 				return err
 			}
-			version, err = container.File(versionMount.Path("version")).Contents(context.Background())
+			images = []engine.Image{{Variants: []*dagger.Container{container.Import(container.File(imageMount.Path("image.tar")))}}}
+		}
+		src := tagSource{time: time.Now()}
+		if requiresVersion(strategies) {
+			src.version = config.Common.Version
+			if src.version == "" {
+				var versionMount *engine.ArtifactMount
+				container, versionMount, err = utils.Mount(container, engine.SemanticVersionArtifact)
+				if err != nil {
+					return err
+				}
+				src.version, err = container.File(versionMount.Path("version")).Contents(context.Background())
+				if err != nil {
+					return err
+				}
+				src.version = strings.ReplaceAll(src.version, "\n", "")
+			}
+		}
+		if requiresGit(strategies) {
+			src.sha, src.branch, err = gitRevision(container)
 			if err != nil {
 				return err
 			}
 		}
-		container = container.Import(container.File(imageMount.Path("image.tar")))
-		container = container.WithRegistryAuth(
-			args.CONTAINER_REGISTRY,
-			args.CONTAINER_REGISTRY_USERNAME,
-			utils.SetSecret("registryPassword", args.CONTAINER_REGISTRY_PASSWORD),
-		)
-		_, err = container.Publish(
-			context.Background(),
-			fmt.Sprintf("%s:%s", args.CONTAINER_REGISTRY, strings.ReplaceAll(version, "\n", "")),
-		)
+		tags, err := imageTags(strategies, config.Container.MainBranch, src)
 		if err != nil {
 			return err
 		}
-		return err
+		registryPassword := utils.SetSecret("registryPassword", args.CONTAINER_REGISTRY_PASSWORD)
+		digests := []string{}
+		for _, image := range images {
+			repository, ok := repositories[image.Name]
+			if !ok {
+				repository = imageRepository(args.CONTAINER_REGISTRY, engine.ConfigContainerImage{Name: image.Name})
+			}
+			publishOpts := dagger.ContainerPublishOpts{}
+			// multi-platform images are published as a manifest list of
+			// the platform variants.
+			if len(image.Variants) > 1 {
+				publishOpts.PlatformVariants = image.Variants[1:]
+			}
+			variant := image.Variants[0].WithRegistryAuth(args.CONTAINER_REGISTRY, args.CONTAINER_REGISTRY_USERNAME, registryPassword)
+			var ref string
+			for _, tag := range tags {
+				ref, err = variant.Publish(context.Background(), fmt.Sprintf("%s:%s", repository, tag), publishOpts)
+				if err != nil {
+					return err
+				}
+			}
+			digest := ref
+			if _, sha, ok := strings.Cut(ref, "@"); ok {
+				digest = fmt.Sprintf("%s@%s", repository, sha)
+			}
+			digests = append(digests, digest)
+		}
+		container = container.WithNewFile("/tmp/digest", dagger.ContainerWithNewFileOpts{Contents: strings.Join(digests, "\n") + "\n"})
+		return utils.Export(container, engine.ImageDigestArtifact, "/tmp/digest")
 	},
 	Inputs: []engine.InputField{
 		engine.ContainerRegistry,
@@ -0,0 +1,86 @@
+package container
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenameBinariesScript(t *testing.T) {
+	tests := []struct {
+		name     string
+		platform string
+		binaries []string
+		expected []string
+	}{
+		{
+			// [golang] targets without [container] platforms are built for
+			// the engine platform.
+			name:     "engine platform",
+			platform: "linux/amd64",
+			binaries: []string{"app_linux_amd64", "app_linux_arm64", "checksums.txt"},
+			expected: []string{"app", "app_linux_arm64", "checksums.txt"},
+		},
+		{
+			name:     "platform variant",
+			platform: "linux/arm/v7",
+			binaries: []string{"app_linux_arm_v7", "worker_linux_arm_v7"},
+			expected: []string{"app", "worker"},
+		},
+		{
+			name:     "windows",
+			platform: "windows/amd64",
+			binaries: []string{"app_windows_amd64.exe", "app_linux_amd64"},
+			expected: []string{"app.exe", "app_linux_amd64"},
+		},
+		{
+			name:     "unsuffixed",
+			platform: "linux/amd64",
+			binaries: []string{"app"},
+			expected: []string{"app"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d, err := os.MkdirTemp("", "test-build")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(d)
+			if err := os.Mkdir(filepath.Join(d, ".build"), 0755); err != nil {
+				t.Fatal(err)
+			}
+			for _, name := range test.binaries {
+				if err := os.WriteFile(filepath.Join(d, ".build", name), []byte{}, 0755); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if out, err := exec.Command("/bin/sh", "-c", renameBinariesScript(d, test.platform)).CombinedOutput(); err != nil {
+				t.Fatal(string(out))
+			}
+			entries, err := os.ReadDir(filepath.Join(d, ".build"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			names := []string{}
+			for _, entry := range entries {
+				names = append(names, entry.Name())
+			}
+			sort.Strings(names)
+			assert.Equal(t, test.expected, names)
+		})
+	}
+
+	t.Run("no build directory", func(t *testing.T) {
+		d, err := os.MkdirTemp("", "test-build")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(d)
+		assert.NoError(t, exec.Command("/bin/sh", "-c", renameBinariesScript(d, "linux/amd64")).Run())
+	})
+}
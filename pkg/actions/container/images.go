@@ -0,0 +1,125 @@
+package container
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"dagger.io/dagger"
+	"github.com/trustacks/trustacks/pkg/engine"
+)
+
+// sourceImages returns the images declared in the configuration, or
+// the default image that is built from the root Dockerfile or
+// Containerfile.
+func sourceImages(config *engine.Config) ([]engine.ConfigContainerImage, error) {
+	if len(config.Container.Images) == 0 {
+		return []engine.ConfigContainerImage{{}}, nil
+	}
+	names := map[string]bool{}
+	for _, image := range config.Container.Images {
+		if names[image.Name] {
+			return nil, fmt.Errorf("container image '%s' is declared more than once", image.Name)
+		}
+		names[image.Name] = true
+	}
+	return config.Container.Images, nil
+}
+
+// buildContext returns the build context of the image relative to the
+// source root.
+func buildContext(image engine.ConfigContainerImage) string {
+	if image.Context == "" {
+		return "."
+	}
+	return filepath.Clean(image.Context)
+}
+
+// containerfiles returns the paths of the image containerfiles that
+// exist in the source.
+func containerfiles(source string, image engine.ConfigContainerImage) []string {
+	candidates := []string{
+		filepath.Join(buildContext(image), "Dockerfile"),
+		filepath.Join(buildContext(image), "Containerfile"),
+	}
+	if image.Dockerfile != "" {
+		candidates = []string{image.Dockerfile}
+	}
+	paths := []string{}
+	for _, path := range candidates {
+		if _, err := os.Stat(filepath.Join(source, path)); !os.IsNotExist(err) {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// dockerfilePath returns the dockerfile path relative to the build
+// context.
+func dockerfilePath(image engine.ConfigContainerImage) (string, error) {
+	if image.Dockerfile == "" {
+		return "", nil
+	}
+	path, err := filepath.Rel(buildContext(image), filepath.Clean(image.Dockerfile))
+	if err != nil {
+		return "", err
+	}
+	if strings.HasPrefix(path, "..") {
+		return "", fmt.Errorf("dockerfile '%s' is outside of the build context '%s'", image.Dockerfile, buildContext(image))
+	}
+	return path, nil
+}
+
+// buildArgs returns the image build args in name order. Values can
+// reference inputs and environment variables (ie. ${GITHUB_TOKEN}).
+func buildArgs(image engine.ConfigContainerImage) []dagger.BuildArg {
+	names := make([]string, 0, len(image.BuildArgs))
+	for name := range image.BuildArgs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	args := []dagger.BuildArg{}
+	for _, name := range names {
+		args = append(args, dagger.BuildArg{Name: name, Value: os.ExpandEnv(image.BuildArgs[name])})
+	}
+	return args
+}
+
+// dockerBuildOpts creates the build options for the image. Secrets
+// are read from inputs and environment variables and are mounted at
+// /run/secrets/<name> during the build.
+func dockerBuildOpts(image engine.ConfigContainerImage, platform string, utils *engine.ActionUtilities) (dagger.DirectoryDockerBuildOpts, error) {
+	dockerfile, err := dockerfilePath(image)
+	if err != nil {
+		return dagger.DirectoryDockerBuildOpts{}, err
+	}
+	opts := dagger.DirectoryDockerBuildOpts{
+		Dockerfile: dockerfile,
+		Platform:   dagger.Platform(platform),
+		BuildArgs:  buildArgs(image),
+		Target:     image.Target,
+	}
+	for _, name := range image.Secrets {
+		value := os.Getenv(name)
+		if value == "" {
+			return opts, fmt.Errorf("build secret '%s' for container image '%s' is not set", name, image.Name)
+		}
+		opts.Secrets = append(opts.Secrets, utils.SetSecret(name, value))
+	}
+	return opts, nil
+}
+
+// imageRepository returns the repository that the image is published
+// to. Named images are published below the container registry unless a
+// repository is declared.
+func imageRepository(registry string, image engine.ConfigContainerImage) string {
+	if image.Repository != "" {
+		return image.Repository
+	}
+	if image.Name == "" {
+		return registry
+	}
+	return fmt.Sprintf("%s/%s", strings.TrimSuffix(registry, "/"), image.Name)
+}
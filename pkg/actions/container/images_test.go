@@ -0,0 +1,60 @@
+package container
+
+import (
+	"testing"
+
+	"dagger.io/dagger"
+	"github.com/stretchr/testify/assert"
+	"github.com/trustacks/trustacks/pkg/engine"
+)
+
+func TestSourceImages(t *testing.T) {
+	images, err := sourceImages(&engine.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []engine.ConfigContainerImage{{}}, images)
+
+	_, err = sourceImages(&engine.Config{Container: engine.ConfigContainer{
+		Images: []engine.ConfigContainerImage{{Name: "api"}, {Name: "api"}},
+	}})
+	assert.ErrorContains(t, err, "container image 'api' is declared more than once")
+}
+
+func TestDockerfilePath(t *testing.T) {
+	tests := []struct {
+		image engine.ConfigContainerImage
+		path  string
+	}{
+		{engine.ConfigContainerImage{}, ""},
+		{engine.ConfigContainerImage{Dockerfile: "build/api.Dockerfile"}, "build/api.Dockerfile"},
+		{engine.ConfigContainerImage{Dockerfile: "api/Dockerfile", Context: "api"}, "Dockerfile"},
+	}
+	for _, tc := range tests {
+		path, err := dockerfilePath(tc.image)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, tc.path, path)
+	}
+	_, err := dockerfilePath(engine.ConfigContainerImage{Dockerfile: "Dockerfile", Context: "api"})
+	assert.ErrorContains(t, err, "outside of the build context")
+}
+
+func TestBuildArgs(t *testing.T) {
+	t.Setenv("NPM_TOKEN", "secret")
+	args := buildArgs(engine.ConfigContainerImage{BuildArgs: map[string]string{
+		"VERSION": "1.0.0",
+		"TOKEN":   "${NPM_TOKEN}",
+	}})
+	assert.Equal(t, []dagger.BuildArg{
+		{Name: "TOKEN", Value: "secret"},
+		{Name: "VERSION", Value: "1.0.0"},
+	}, args)
+}
+
+func TestImageRepository(t *testing.T) {
+	assert.Equal(t, "docker.io/org/app", imageRepository("docker.io/org/app", engine.ConfigContainerImage{}))
+	assert.Equal(t, "docker.io/org/app/api", imageRepository("docker.io/org/app", engine.ConfigContainerImage{Name: "api"}))
+	assert.Equal(t, "ghcr.io/org/api", imageRepository("docker.io/org/app", engine.ConfigContainerImage{Name: "api", Repository: "ghcr.io/org/api"}))
+}
@@ -7,6 +7,8 @@ import (
 	"strings"
 
 	mapset "github.com/deckarep/golang-set/v2"
+	"github.com/trustacks/trustacks/pkg/actions/javascript"
+	"github.com/trustacks/trustacks/pkg/actions/python"
 	"github.com/trustacks/trustacks/pkg/engine"
 )
 
@@ -15,59 +17,159 @@ var (
 	ContainerfileHasPredictableDependenciesFact = engine.NewFact()
 )
 
+// hasNoDependencies checks that the sources of the image containerfile
+// copy instructions exist in the build context.
+func hasNoDependencies(source string, image engine.ConfigContainerImage) (bool, error) {
+	for _, file := range containerfiles(source, image) {
+		contents, err := os.ReadFile(filepath.Join(source, file))
+		if err != nil {
+			return false, err
+		}
+		re := regexp.MustCompile(`COPY\s(.*?)\s`)
+		matches := re.FindAllStringSubmatch(string(contents), -1)
+		for _, match := range matches {
+			copyCmd := match[1]
+			if copyCmd == "." || strings.Contains(copyCmd, "--from=") {
+				continue
+			}
+			if _, err := os.Stat(filepath.Join(source, buildContext(image), copyCmd)); os.IsNotExist(err) {
+				return false, nil
+			}
+		}
+	}
+	return true, nil
+}
+
+// buildOutputDirs returns the build artifact directories that can be
+// copied into images. The golang build output is .build, python
+// packages are built to dist and javascript builds use the build output
+// of the framework (ie. dist).
+func buildOutputDirs(source string) ([]string, error) {
+	dirs := []string{".build"}
+	pythonBuild, err := python.HasBuildSystem(source)
+	if err != nil {
+		return nil, err
+	}
+	if pythonBuild {
+		dirs = append(dirs, "dist")
+	}
+	if _, err := os.Stat(filepath.Join(source, "package.json")); os.IsNotExist(err) {
+		return dirs, nil
+	}
+	build, err := javascript.SourceBuild(source)
+	if err == javascript.ErrNoBuild {
+		return dirs, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return append(dirs, build.OutputRoot()), nil
+}
+
+// hasBuildCopy checks that the image containerfile copies a build
+// output directory and that the directory does not exist in the build
+// context.
+func hasBuildCopy(source string, image engine.ConfigContainerImage) (bool, error) {
+	files := containerfiles(source, image)
+	if len(files) == 0 {
+		return false, nil
+	}
+	dirs, err := buildOutputDirs(source)
+	if err != nil {
+		return false, err
+	}
+	for _, file := range files {
+		contents, err := os.ReadFile(filepath.Join(source, file))
+		if err != nil {
+			return false, err
+		}
+		copied := false
+		for _, dir := range dirs {
+			if _, err := os.Stat(filepath.Join(source, buildContext(image), dir)); !os.IsNotExist(err) {
+				continue
+			}
+			re := regexp.MustCompile(`COPY\s+(--\S+\s+)*(\./)?` + regexp.QuoteMeta(dir) + `(/|\s)`)
+			if re.Match(contents) {
+				copied = true
+				break
+			}
+		}
+		if !copied {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
 var ContainerfileExistsRule engine.Rule = func(source string, _ engine.Collector, _ mapset.Set[engine.Fact]) (engine.Fact, error) {
 	var fact = engine.NilFact
-	for _, file := range []string{"Dockerfile", "Containerfile"} {
-		if _, err := os.Stat(filepath.Join(source, file)); !os.IsNotExist(err) {
-			fact = ContainerfileExistFact
+	config, err := engine.NewSourceConfig(source)
+	if err != nil {
+		return fact, err
+	}
+	images, err := sourceImages(config)
+	if err != nil {
+		return fact, err
+	}
+	for _, image := range images {
+		if len(containerfiles(source, image)) == 0 {
+			return fact, nil
 		}
 	}
+	fact = ContainerfileExistFact
 	return fact, nil
 }
 
 var ContainerfileHasNoDependenciesRule engine.Rule = func(source string, _ engine.Collector, _ mapset.Set[engine.Fact]) (engine.Fact, error) {
 	var fact = engine.NilFact
-	for _, file := range []string{"Dockerfile", "Containerfile"} {
-		if _, err := os.Stat(filepath.Join(source, file)); !os.IsNotExist(err) {
-			contents, err := os.ReadFile(filepath.Join(source, file))
-			if err != nil {
-				return fact, err
-			}
-			re := regexp.MustCompile(`COPY\s(.*?)\s`)
-			matches := re.FindAllStringSubmatch(string(contents), -1)
-			for _, match := range matches {
-				copyCmd := match[1]
-				if copyCmd == "." || strings.Contains(copyCmd, "--from=") {
-					continue
-				}
-				if _, err := os.Stat(filepath.Join(source, copyCmd)); os.IsNotExist(err) {
-					return fact, nil
-				}
-			}
+	config, err := engine.NewSourceConfig(source)
+	if err != nil {
+		return fact, err
+	}
+	images, err := sourceImages(config)
+	if err != nil {
+		return fact, err
+	}
+	for _, image := range images {
+		ok, err := hasNoDependencies(source, image)
+		if err != nil || !ok {
+			return fact, err
 		}
 	}
 	fact = ContainerfileHasPredictableDependenciesFact
 	return fact, nil
 }
 
+// ContainerfileHasBuildCopyRule is true if at least one image copies
+// the .build directory and the dependencies of the other images are
+// predictable.
 var ContainerfileHasBuildCopyRule engine.Rule = func(source string, _ engine.Collector, _ mapset.Set[engine.Fact]) (engine.Fact, error) {
 	var fact = engine.NilFact
-	if _, err := os.Stat(filepath.Join(source, ".build")); !os.IsNotExist(err) {
-		return fact, nil
-	}
-	for _, file := range []string{"Dockerfile", "Containerfile"} {
-		if _, err := os.Stat(filepath.Join(source, file)); !os.IsNotExist(err) {
-			contents, err := os.ReadFile(filepath.Join(source, file))
-			if err != nil {
-				return fact, err
-			}
-			re := regexp.MustCompile(`COPY\s*.build\s*`)
-			if !re.Match(contents) {
-				return fact, err
-			}
+	config, err := engine.NewSourceConfig(source)
+	if err != nil {
+		return fact, err
+	}
+	images, err := sourceImages(config)
+	if err != nil {
+		return fact, err
+	}
+	buildCopy := false
+	for _, image := range images {
+		ok, err := hasBuildCopy(source, image)
+		if err != nil {
+			return fact, err
+		}
+		if ok {
+			buildCopy = true
+			continue
+		}
+		ok, err = hasNoDependencies(source, image)
+		if err != nil || !ok {
+			return fact, err
 		}
 	}
-	fact = ContainerfileHasPredictableDependenciesFact
+	if buildCopy {
+		fact = ContainerfileHasPredictableDependenciesFact
+	}
 	return fact, nil
 }
 
@@ -139,6 +139,52 @@ COPY .build /tmp/build`)
 		}
 	})
 
+	t.Run("ContainerfileHasBuildCopyRule is true for javascript build outputs", func(t *testing.T) {
+		d, err := os.MkdirTemp("", "test-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(d)
+		if err := os.WriteFile(filepath.Join(d, "package.json"), []byte(`{"scripts": {"build": "vite build"}}`), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(d, "vite.config.js"), []byte(`export default {}`), 0644); err != nil {
+			t.Fatal(err)
+		}
+		contents := []byte(`FROM nginx
+COPY dist/ /usr/share/nginx/html`)
+		if err := os.WriteFile(filepath.Join(d, "Dockerfile"), contents, 0644); err != nil {
+			t.Fatal(err)
+		}
+		fact, err := ContainerfileHasBuildCopyRule(d, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, fact, ContainerfileHasPredictableDependenciesFact)
+	})
+
+	t.Run("ContainerfileHasBuildCopyRule is true for python packages", func(t *testing.T) {
+		d, err := os.MkdirTemp("", "test-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(d)
+		if err := os.WriteFile(filepath.Join(d, "pyproject.toml"), []byte("[build-system]\nrequires = [\"hatchling\"]\nbuild-backend = \"hatchling.build\"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		contents := []byte(`FROM python
+COPY dist/*.whl /tmp/
+RUN pip install /tmp/*.whl`)
+		if err := os.WriteFile(filepath.Join(d, "Dockerfile"), contents, 0644); err != nil {
+			t.Fatal(err)
+		}
+		fact, err := ContainerfileHasBuildCopyRule(d, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, fact, ContainerfileHasPredictableDependenciesFact)
+	})
+
 	t.Run("ContainerfileHasBuildCopyRule is false if .build directory exists", func(t *testing.T) {
 		d, err := os.MkdirTemp("", "test-src")
 		if err != nil {
@@ -176,3 +222,68 @@ COPY test.txt /tmp/test.txt`)
 		assert.NotEqual(t, fact, ContainerfileHasPredictableDependenciesFact)
 	})
 }
+
+func TestContainerRulesWithDeclaredImages(t *testing.T) {
+	d, err := os.MkdirTemp("", "test-src")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(d)
+	config := `
+[[container.images]]
+name = "api"
+dockerfile = "build/api.Dockerfile"
+
+[[container.images]]
+name = "worker"
+context = "worker"`
+	if err := os.WriteFile(filepath.Join(d, "trustacks.toml"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	fact, err := ContainerfileExistsRule(d, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NotEqual(t, fact, ContainerfileExistFact)
+
+	if err := os.MkdirAll(filepath.Join(d, "build"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(d, "build", "api.Dockerfile"), []byte(`FROM alpine
+COPY .build /tmp/build`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(d, "worker"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(d, "worker", "Dockerfile"), []byte(`FROM alpine
+COPY worker.sh /tmp/worker.sh`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	fact, err = ContainerfileExistsRule(d, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, fact, ContainerfileExistFact)
+
+	// the worker copy source does not exist in the worker context.
+	fact, err = ContainerfileHasBuildCopyRule(d, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NotEqual(t, fact, ContainerfileHasPredictableDependenciesFact)
+
+	if err := os.WriteFile(filepath.Join(d, "worker", "worker.sh"), []byte(""), 0644); err != nil {
+		t.Fatal(err)
+	}
+	fact, err = ContainerfileHasBuildCopyRule(d, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, fact, ContainerfileHasPredictableDependenciesFact)
+	fact, err = ContainerfileHasNoDependenciesRule(d, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NotEqual(t, fact, ContainerfileHasPredictableDependenciesFact)
+}
@@ -0,0 +1,124 @@
+package container
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	VersionTagStrategy   = "version"
+	SemverTagStrategy    = "semver"
+	SHATagStrategy       = "sha"
+	BranchTagStrategy    = "branch"
+	LatestTagStrategy    = "latest"
+	TimestampTagStrategy = "timestamp"
+)
+
+const defaultMainBranch = "main"
+
+var (
+	semverPattern     = regexp.MustCompile(`^v?(\d+)\.(\d+)\.(\d+)(-[0-9A-Za-z.-]+)?(\+[0-9A-Za-z.-]+)?$`)
+	invalidTagPattern = regexp.MustCompile(`[^A-Za-z0-9_.-]`)
+)
+
+// tagSource contains the build details that image tags are derived
+// from.
+type tagSource struct {
+	version string
+	sha     string
+	branch  string
+	time    time.Time
+}
+
+// requiresVersion returns true if any of the strategies tag the image
+// with the semantic version.
+func requiresVersion(strategies []string) bool {
+	for _, strategy := range strategies {
+		if strategy == VersionTagStrategy || strategy == SemverTagStrategy {
+			return true
+		}
+	}
+	return false
+}
+
+// requiresGit returns true if any of the strategies tag the image with
+// details from the git repository.
+func requiresGit(strategies []string) bool {
+	for _, strategy := range strategies {
+		if strategy == SHATagStrategy || strategy == BranchTagStrategy || strategy == LatestTagStrategy {
+			return true
+		}
+	}
+	return false
+}
+
+// imageTags returns the unique image tags for the strategies in the
+// order that they were declared.
+func imageTags(strategies []string, mainBranch string, src tagSource) ([]string, error) {
+	if mainBranch == "" {
+		mainBranch = defaultMainBranch
+	}
+	tags := []string{}
+	seen := map[string]bool{}
+	add := func(tag string) {
+		if tag != "" && !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+	for _, strategy := range strategies {
+		switch strategy {
+		case VersionTagStrategy:
+			add(sanitizeTag(src.version))
+		case SemverTagStrategy:
+			semverTags, err := semverTags(src.version)
+			if err != nil {
+				return nil, err
+			}
+			for _, tag := range semverTags {
+				add(tag)
+			}
+		case SHATagStrategy:
+			add(src.sha)
+		case BranchTagStrategy:
+			add(sanitizeTag(src.branch))
+		case LatestTagStrategy:
+			if src.branch == mainBranch {
+				add("latest")
+			}
+		case TimestampTagStrategy:
+			add(src.time.UTC().Format("20060102150405"))
+		default:
+			return nil, fmt.Errorf("unknown container tag strategy '%s'", strategy)
+		}
+	}
+	if len(tags) == 0 {
+		return nil, fmt.Errorf("the container tag strategies %v did not produce any tags", strategies)
+	}
+	return tags, nil
+}
+
+// semverTags returns the full version and the major and minor version
+// aliases. Prereleases are only tagged with the full version.
+func semverTags(version string) ([]string, error) {
+	match := semverPattern.FindStringSubmatch(version)
+	if match == nil {
+		return nil, fmt.Errorf("'%s' is not a semantic version", version)
+	}
+	full := sanitizeTag(strings.TrimPrefix(version, "v"))
+	if match[4] != "" {
+		return []string{full}, nil
+	}
+	return []string{
+		full,
+		fmt.Sprintf("%s.%s", match[1], match[2]),
+		match[1],
+	}, nil
+}
+
+// sanitizeTag replaces characters that are not allowed in image tags.
+func sanitizeTag(tag string) string {
+	return invalidTagPattern.ReplaceAllString(strings.TrimSpace(tag), "-")
+}
@@ -0,0 +1,65 @@
+package container
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImageTags(t *testing.T) {
+	src := tagSource{
+		version: "1.2.3",
+		sha:     "a1b2c3d",
+		branch:  "main",
+		time:    time.Date(2023, 12, 9, 10, 30, 0, 0, time.UTC),
+	}
+	tests := []struct {
+		strategies []string
+		mainBranch string
+		src        tagSource
+		tags       []string
+	}{
+		{[]string{VersionTagStrategy}, "", src, []string{"1.2.3"}},
+		{[]string{SemverTagStrategy}, "", src, []string{"1.2.3", "1.2", "1"}},
+		{[]string{SemverTagStrategy, VersionTagStrategy}, "", src, []string{"1.2.3", "1.2", "1"}},
+		{[]string{SHATagStrategy, BranchTagStrategy, LatestTagStrategy}, "", src, []string{"a1b2c3d", "main", "latest"}},
+		{[]string{LatestTagStrategy, SHATagStrategy}, "trunk", src, []string{"a1b2c3d"}},
+		{[]string{BranchTagStrategy}, "", tagSource{branch: "feature/login"}, []string{"feature-login"}},
+		{[]string{TimestampTagStrategy}, "", src, []string{"20231209103000"}},
+	}
+	for _, tc := range tests {
+		tags, err := imageTags(tc.strategies, tc.mainBranch, tc.src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, tc.tags, tags)
+	}
+
+	t.Run("unknown strategy", func(t *testing.T) {
+		_, err := imageTags([]string{"random"}, "", src)
+		assert.ErrorContains(t, err, "unknown container tag strategy 'random'")
+	})
+
+	t.Run("no tags", func(t *testing.T) {
+		_, err := imageTags([]string{LatestTagStrategy}, "", tagSource{branch: "develop"})
+		assert.Error(t, err)
+	})
+}
+
+func TestSemverTags(t *testing.T) {
+	tags, err := semverTags("v2.0.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"2.0.1", "2.0", "2"}, tags)
+
+	tags, err = semverTags("2.1.0-rc.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"2.1.0-rc.1"}, tags)
+
+	_, err = semverTags("latest")
+	assert.ErrorContains(t, err, "'latest' is not a semantic version")
+}
@@ -0,0 +1,48 @@
+package cypress
+
+import (
+	"dagger.io/dagger"
+	"github.com/trustacks/trustacks/pkg/actions/javascript"
+	"github.com/trustacks/trustacks/pkg/engine"
+)
+
+var cypressRunAction = &engine.Action{
+	Name:        "cypressRun",
+	DisplayName: "Cypress Run",
+	Description: "Run the end-to-end test suite against the built application with cypress.",
+	Image:       func(_ *engine.Config) string { return "cypress/base" },
+	Stage:       engine.AcceptanceStage,
+	Caches:      append([]string{"/root/.cache/Cypress"}, javascript.Caches...),
+	OptionalInputArtifacts: []engine.Artifact{
+		engine.ContainerImageArtifact,
+		engine.BuildArtifact,
+	},
+	Script: func(container *dagger.Container, _ map[string]interface{}, utils *engine.ActionUtilities) error {
+		container, manager, err := javascript.InstallDependencies(container)
+		if err != nil {
+			return err
+		}
+		container, url, err := javascript.WithAppService(container, utils)
+		if err != nil {
+			return err
+		}
+		// CYPRESS_BASE_URL overrides the baseUrl of the cypress
+		// configuration.
+		container = container.
+			WithEnvVariable("CI", "true").
+			WithEnvVariable("BASE_URL", url).
+			WithEnvVariable("CYPRESS_BASE_URL", url)
+		return javascript.RunE2E(
+			container,
+			javascript.ExecArgs(manager, "cypress", "run"),
+			[]string{"cypress/screenshots", "cypress/videos"},
+			"cypress",
+			utils,
+		)
+	},
+	AdmissionCriteria: []engine.Fact{CypressConfigExistsFact},
+}
+
+func init() {
+	engine.RegisterAction(cypressRunAction)
+}
@@ -0,0 +1,32 @@
+package cypress
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	mapset "github.com/deckarep/golang-set/v2"
+	"github.com/trustacks/trustacks/pkg/actions/javascript"
+	"github.com/trustacks/trustacks/pkg/engine"
+)
+
+var (
+	// CypressConfigExistsFact is true if the cypress configuration exists
+	// in the root of the application source.
+	CypressConfigExistsFact = engine.NewFact()
+)
+
+var CypressConfigExistsRule engine.Rule = func(source string, _ engine.Collector, _ mapset.Set[engine.Fact]) (engine.Fact, error) {
+	var fact = engine.NilFact
+	for _, ext := range []string{"ts", "js", "mjs", "cjs", "mts", "cts"} {
+		if _, err := os.Stat(filepath.Join(source, fmt.Sprintf("cypress.config.%s", ext))); !os.IsNotExist(err) {
+			fact = CypressConfigExistsFact
+			break
+		}
+	}
+	return fact, nil
+}
+
+func init() {
+	engine.AddToRuleset(&javascript.PackageJSONExistsRule, &CypressConfigExistsRule)
+}
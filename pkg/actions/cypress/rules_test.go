@@ -0,0 +1,49 @@
+package cypress
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCypressConfigExistsRule(t *testing.T) {
+	t.Run("CypressConfigExistsFact is true", func(t *testing.T) {
+		tempDirs := []string{}
+		defer func() {
+			for _, d := range tempDirs {
+				os.RemoveAll(d)
+			}
+		}()
+		for _, ext := range []string{"ts", "js", "mjs", "cjs"} {
+			d, err := os.MkdirTemp("", "test-src")
+			if err != nil {
+				t.Fatal(err)
+			}
+			tempDirs = append(tempDirs, d)
+			if err := os.WriteFile(filepath.Join(d, fmt.Sprintf("cypress.config.%s", ext)), []byte(""), 0744); err != nil {
+				t.Fatal(err)
+			}
+			fact, err := CypressConfigExistsRule(d, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, fact, CypressConfigExistsFact)
+		}
+	})
+
+	t.Run("CypressConfigExistsFact is false", func(t *testing.T) {
+		d, err := os.MkdirTemp("", "test-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(d)
+		fact, err := CypressConfigExistsRule(d, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.NotEqual(t, fact, CypressConfigExistsFact)
+	})
+}
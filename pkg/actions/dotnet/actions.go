@@ -0,0 +1,121 @@
+package dotnet
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"dagger.io/dagger"
+	"github.com/trustacks/trustacks/pkg/engine"
+)
+
+// caches are the nuget package caches.
+var caches = []string{"/root/.nuget/packages"}
+
+// withSdk initializes the container from the sdk image of the
+// global.json sdk version and restores the dependencies of the root
+// solution or project. The restored container and the root target are
+// returned.
+func withSdk(container *dagger.Container, utils *engine.ActionUtilities) (*dagger.Container, string, error) {
+	entries, err := container.Directory("/src").Entries(context.Background())
+	if err != nil {
+		return nil, "", err
+	}
+	if utils.HasFact(GlobalJSONExistsFact) {
+		data, err := engine.ContainerFileReader(container)("global.json")
+		if err != nil {
+			return nil, "", err
+		}
+		channel, err := sdkChannel(data)
+		if err != nil {
+			return nil, "", err
+		}
+		if channel != defaultChannel {
+			container = engine.WithImage(container, fmt.Sprintf("sdk-%s", channel), sdkImage(channel))
+		}
+	}
+	target := rootTarget(entries)
+	container = container.
+		WithEnvVariable("DOTNET_CLI_TELEMETRY_OPTOUT", "1").
+		WithEnvVariable("DOTNET_NOLOGO", "1").
+		WithExec([]string{"dotnet", "restore", target})
+	return container, target, nil
+}
+
+var dotnetTest = &engine.Action{
+	Name:        "dotnetTest",
+	DisplayName: "Dotnet Test",
+	Description: "Run the .NET test suite with dotnet test.",
+	Image:       func(_ *engine.Config) string { return sdkImage(defaultChannel) },
+	Stage:       engine.CommitStage,
+	Caches:      caches,
+	OutputArtifacts: []engine.Artifact{
+		engine.CoverageArtifact,
+	},
+	Script: func(container *dagger.Container, _ map[string]interface{}, utils *engine.ActionUtilities) error {
+		container, target, err := withSdk(container, utils)
+		if err != nil {
+			return err
+		}
+		container = container.
+			WithExec([]string{"dotnet", "test", target, "--no-restore", "--collect:XPlat Code Coverage", "--results-directory", "/tmp/test-results"}).
+			WithExec([]string{"/bin/sh", "-c", coverageScript("/tmp/test-results", "/tmp/coverage.cobertura.xml")})
+		// the cobertura reports are only collected by test projects
+		// that reference the coverlet collector.
+		entries, err := container.Directory("/tmp").Entries(context.Background())
+		if err != nil {
+			return err
+		}
+		if engine.HasEntry(entries, "coverage.cobertura.xml") {
+			if err := utils.Export(container, engine.CoverageArtifact, "/tmp/coverage.cobertura.xml"); err != nil {
+				return err
+			}
+		}
+		_, err = container.Sync(context.Background())
+		return err
+	},
+	AdmissionCriteria: []engine.Fact{DotnetProjectExistsFact},
+}
+
+var dotnetPublish = &engine.Action{
+	Name:        "dotnetPublish",
+	DisplayName: "Dotnet Publish",
+	Description: "Publish the .NET applications with dotnet publish.",
+	Image:       func(_ *engine.Config) string { return sdkImage(defaultChannel) },
+	Stage:       engine.OnDemand,
+	Caches:      caches,
+	OutputArtifacts: []engine.Artifact{
+		engine.BuildArtifact,
+	},
+	Script: func(container *dagger.Container, _ map[string]interface{}, utils *engine.ActionUtilities) error {
+		container, target, err := withSdk(container, utils)
+		if err != nil {
+			return err
+		}
+		projects, err := publishProjects(target, engine.ContainerFileReader(container))
+		if err != nil {
+			return err
+		}
+		// each application is published to its own directory, so that
+		// the shared dependencies of the applications do not conflict.
+		for _, project := range projects {
+			container = container.WithExec([]string{
+				"dotnet", "publish", project,
+				"--no-restore",
+				"--configuration", "Release",
+				"--output", path.Join("/tmp/.build", projectName(project)),
+			})
+		}
+		if err := utils.Export(container, engine.BuildArtifact, "/tmp/.build"); err != nil {
+			return err
+		}
+		_, err = container.Sync(context.Background())
+		return err
+	},
+	AdmissionCriteria: []engine.Fact{DotnetExecutableExistsFact},
+}
+
+func init() {
+	engine.RegisterAction(dotnetTest)
+	engine.RegisterAction(dotnetPublish)
+}
@@ -0,0 +1,191 @@
+package dotnet
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/trustacks/trustacks/pkg/engine"
+)
+
+// defaultChannel is the sdk channel used when the global.json does not
+// pin the sdk version.
+const defaultChannel = "8.0"
+
+// projectExtensions are the msbuild project file extensions.
+var projectExtensions = []string{".csproj", ".fsproj", ".vbproj"}
+
+// solutionProjectPattern matches the project entries of a solution file
+// (ie. Project("{GUID}") = "App", "src\App\App.csproj", "{GUID}").
+var solutionProjectPattern = regexp.MustCompile(`(?m)^Project\("\{[^}]+\}"\)\s*=\s*"[^"]*",\s*"([^"]+\.(?:cs|fs|vb)proj)"`)
+
+// globalJSON is the subset of the global.json that pins the sdk.
+type globalJSON struct {
+	Sdk struct {
+		Version string `json:"version"`
+	} `json:"sdk"`
+}
+
+// sdkChannel returns the major.minor sdk channel of the global.json sdk
+// version (ie. 8.0.100 => 8.0). The default channel is returned if the
+// version is not pinned.
+func sdkChannel(data []byte) (string, error) {
+	var config globalJSON
+	if err := json.Unmarshal(data, &config); err != nil {
+		return "", err
+	}
+	if config.Sdk.Version == "" {
+		return defaultChannel, nil
+	}
+	parts := strings.Split(config.Sdk.Version, ".")
+	if len(parts) < 2 {
+		return "", fmt.Errorf("invalid sdk version: %s", config.Sdk.Version)
+	}
+	return parts[0] + "." + parts[1], nil
+}
+
+// sdkImage returns the sdk image of the channel.
+func sdkImage(channel string) string {
+	return fmt.Sprintf("mcr.microsoft.com/dotnet/sdk:%s", channel)
+}
+
+// isProject returns true if the file name is a msbuild project.
+func isProject(name string) bool {
+	for _, ext := range projectExtensions {
+		if strings.HasSuffix(name, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// rootTarget returns the solution or project in the source root that
+// the dotnet commands run against. Solutions take precedence over
+// projects.
+func rootTarget(entries []string) string {
+	solutions, projects := []string{}, []string{}
+	for _, entry := range entries {
+		switch {
+		case strings.HasSuffix(entry, ".sln"):
+			solutions = append(solutions, entry)
+		case isProject(entry):
+			projects = append(projects, entry)
+		}
+	}
+	sort.Strings(solutions)
+	sort.Strings(projects)
+	if len(solutions) > 0 {
+		return solutions[0]
+	}
+	if len(projects) > 0 {
+		return projects[0]
+	}
+	return ""
+}
+
+// solutionProjects returns the project paths of the solution with
+// forward slash separators.
+func solutionProjects(data []byte) []string {
+	projects := []string{}
+	for _, match := range solutionProjectPattern.FindAllSubmatch(data, -1) {
+		projects = append(projects, strings.ReplaceAll(string(match[1]), `\`, "/"))
+	}
+	return projects
+}
+
+// msbuildProject is the subset of the project file that describes the
+// project output type.
+type msbuildProject struct {
+	Sdk            string `xml:"Sdk,attr"`
+	PropertyGroups []struct {
+		OutputType    string `xml:"OutputType"`
+		IsTestProject string `xml:"IsTestProject"`
+	} `xml:"PropertyGroup"`
+	ItemGroups []struct {
+		PackageReferences []struct {
+			Include string `xml:"Include,attr"`
+		} `xml:"PackageReference"`
+	} `xml:"ItemGroup"`
+}
+
+// isExecutable returns true if the project builds an application (ie.
+// a console, web or worker application). Test projects are not
+// executable.
+func isExecutable(data []byte) (bool, error) {
+	var project msbuildProject
+	if err := xml.Unmarshal(data, &project); err != nil {
+		return false, err
+	}
+	executable := project.Sdk == "Microsoft.NET.Sdk.Web" || project.Sdk == "Microsoft.NET.Sdk.Worker"
+	for _, group := range project.PropertyGroups {
+		switch strings.ToLower(strings.TrimSpace(group.OutputType)) {
+		case "exe", "winexe":
+			executable = true
+		}
+		if strings.EqualFold(strings.TrimSpace(group.IsTestProject), "true") {
+			return false, nil
+		}
+	}
+	for _, group := range project.ItemGroups {
+		for _, reference := range group.PackageReferences {
+			if reference.Include == "Microsoft.NET.Test.Sdk" {
+				return false, nil
+			}
+		}
+	}
+	return executable, nil
+}
+
+// publishProjects returns the executable projects of the root solution
+// or project.
+func publishProjects(target string, readFile engine.FileReader) ([]string, error) {
+	projects := []string{target}
+	if strings.HasSuffix(target, ".sln") {
+		data, err := readFile(target)
+		if err != nil {
+			return nil, err
+		}
+		projects = solutionProjects(data)
+	}
+	executables := []string{}
+	for _, project := range projects {
+		data, err := readFile(project)
+		if err != nil {
+			return nil, err
+		}
+		executable, err := isExecutable(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", project, err)
+		}
+		if executable {
+			executables = append(executables, project)
+		}
+	}
+	return executables, nil
+}
+
+// projectName returns the project name of the project path (ie.
+// src/App/App.csproj => App).
+func projectName(project string) string {
+	name := path.Base(project)
+	return strings.TrimSuffix(name, path.Ext(name))
+}
+
+// coverageScript merges the cobertura reports of each test project in
+// the results directory into the output file. A single report is
+// copied as is.
+func coverageScript(results, output string) string {
+	return fmt.Sprintf(`reports=$(find %[1]s -name coverage.cobertura.xml)
+count=$(echo "$reports" | grep -c . || true)
+if [ "$count" -eq 1 ]; then
+  cp $reports %[2]s
+elif [ "$count" -gt 1 ]; then
+  dotnet tool install --tool-path /tmp/tools dotnet-reportgenerator-globaltool
+  /tmp/tools/reportgenerator -reports:"%[1]s/**/coverage.cobertura.xml" -targetdir:/tmp/coverage-report -reporttypes:Cobertura
+  cp /tmp/coverage-report/Cobertura.xml %[2]s
+fi`, results, output)
+}
@@ -0,0 +1,98 @@
+package dotnet
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testSolution = `
+Microsoft Visual Studio Solution File, Format Version 12.00
+Project("{FAE04EC0-301F-11D3-BF4B-00C04F79EFBC}") = "Api", "src\Api\Api.csproj", "{0B4B6B5C-0000-0000-0000-000000000001}"
+EndProject
+Project("{FAE04EC0-301F-11D3-BF4B-00C04F79EFBC}") = "Core", "src\Core\Core.csproj", "{0B4B6B5C-0000-0000-0000-000000000002}"
+EndProject
+Project("{FAE04EC0-301F-11D3-BF4B-00C04F79EFBC}") = "Api.Tests", "tests\Api.Tests\Api.Tests.csproj", "{0B4B6B5C-0000-0000-0000-000000000003}"
+EndProject
+Project("{2150E333-8FDC-42A3-9474-1A3956D46DE8}") = "src", "src", "{0B4B6B5C-0000-0000-0000-000000000004}"
+EndProject
+`
+
+const (
+	webProject     = `<Project Sdk="Microsoft.NET.Sdk.Web"><PropertyGroup><TargetFramework>net8.0</TargetFramework></PropertyGroup></Project>`
+	libraryProject = `<Project Sdk="Microsoft.NET.Sdk"><PropertyGroup><TargetFramework>net8.0</TargetFramework></PropertyGroup></Project>`
+	consoleProject = `<Project Sdk="Microsoft.NET.Sdk"><PropertyGroup><OutputType>Exe</OutputType></PropertyGroup></Project>`
+	testProject    = `<Project Sdk="Microsoft.NET.Sdk">
+  <PropertyGroup><OutputType>Exe</OutputType></PropertyGroup>
+  <ItemGroup><PackageReference Include="Microsoft.NET.Test.Sdk" Version="17.8.0" /></ItemGroup>
+</Project>`
+)
+
+func TestSdkChannel(t *testing.T) {
+	channel, err := sdkChannel([]byte(`{"sdk": {"version": "6.0.400", "rollForward": "latestFeature"}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "6.0", channel)
+
+	channel, err = sdkChannel([]byte(`{"msbuild-sdks": {}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, defaultChannel, channel)
+
+	_, err = sdkChannel([]byte(`{"sdk": {"version": "8"}}`))
+	assert.Error(t, err)
+}
+
+func TestRootTarget(t *testing.T) {
+	assert.Equal(t, "App.sln", rootTarget([]string{"App.csproj", "App.sln", "global.json"}))
+	assert.Equal(t, "App.fsproj", rootTarget([]string{"App.fsproj", "README.md"}))
+	assert.Equal(t, "", rootTarget([]string{"README.md"}))
+}
+
+func TestSolutionProjects(t *testing.T) {
+	assert.Equal(t, []string{
+		"src/Api/Api.csproj",
+		"src/Core/Core.csproj",
+		"tests/Api.Tests/Api.Tests.csproj",
+	}, solutionProjects([]byte(testSolution)))
+}
+
+func TestIsExecutable(t *testing.T) {
+	for contents, expected := range map[string]bool{
+		webProject:     true,
+		libraryProject: false,
+		consoleProject: true,
+		testProject:    false,
+	} {
+		executable, err := isExecutable([]byte(contents))
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, expected, executable, contents)
+	}
+}
+
+func TestPublishProjects(t *testing.T) {
+	files := map[string]string{
+		"App.sln":                          testSolution,
+		"src/Api/Api.csproj":               webProject,
+		"src/Core/Core.csproj":             libraryProject,
+		"tests/Api.Tests/Api.Tests.csproj": testProject,
+	}
+	readFile := func(name string) ([]byte, error) {
+		contents, ok := files[name]
+		if !ok {
+			return nil, fmt.Errorf("%s does not exist", name)
+		}
+		return []byte(contents), nil
+	}
+	projects, err := publishProjects("App.sln", readFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"src/Api/Api.csproj"}, projects)
+	assert.Equal(t, "Api", projectName(projects[0]))
+}
@@ -0,0 +1,88 @@
+package dotnet
+
+import (
+	"os"
+	"path/filepath"
+
+	mapset "github.com/deckarep/golang-set/v2"
+	"github.com/trustacks/trustacks/pkg/engine"
+)
+
+var (
+	// DotnetProjectExistsFact is true if a solution (*.sln) or project
+	// (*.csproj, *.fsproj or *.vbproj) exists in the root of the
+	// application source.
+	DotnetProjectExistsFact = engine.NewFact()
+	// GlobalJSONExistsFact is true if the global.json exists in the
+	// root of the application source.
+	GlobalJSONExistsFact = engine.NewFact()
+	// DotnetExecutableExistsFact is true if the root solution or
+	// project builds an application.
+	DotnetExecutableExistsFact = engine.NewFact()
+)
+
+// sourceEntries returns the names of the entries in the source root.
+func sourceEntries(source string) ([]string, error) {
+	dirEntries, err := os.ReadDir(source)
+	if err != nil {
+		return nil, err
+	}
+	entries := []string{}
+	for _, entry := range dirEntries {
+		entries = append(entries, entry.Name())
+	}
+	return entries, nil
+}
+
+// DotnetProjectExistsRule checks if a solution or project exists in the
+// root of the source.
+var DotnetProjectExistsRule engine.Rule = func(source string, _ engine.Collector, _ mapset.Set[engine.Fact]) (engine.Fact, error) {
+	var fact = engine.NilFact
+	entries, err := sourceEntries(source)
+	if err != nil {
+		return fact, err
+	}
+	if rootTarget(entries) != "" {
+		fact = DotnetProjectExistsFact
+	}
+	return fact, nil
+}
+
+// GlobalJSONExistsRule checks if the global.json exists in the root of
+// the source.
+var GlobalJSONExistsRule engine.Rule = func(source string, _ engine.Collector, _ mapset.Set[engine.Fact]) (engine.Fact, error) {
+	var fact = engine.NilFact
+	ok, err := engine.AnyFileExists(source, "global.json")
+	if err != nil {
+		return fact, err
+	}
+	if ok {
+		fact = GlobalJSONExistsFact
+	}
+	return fact, nil
+}
+
+// DotnetExecutableExistsRule checks if the root solution or project
+// has an executable project.
+var DotnetExecutableExistsRule engine.Rule = func(source string, _ engine.Collector, _ mapset.Set[engine.Fact]) (engine.Fact, error) {
+	var fact = engine.NilFact
+	entries, err := sourceEntries(source)
+	if err != nil {
+		return fact, err
+	}
+	projects, err := publishProjects(rootTarget(entries), func(name string) ([]byte, error) {
+		return os.ReadFile(filepath.Join(source, filepath.FromSlash(name)))
+	})
+	if err != nil {
+		return fact, err
+	}
+	if len(projects) > 0 {
+		fact = DotnetExecutableExistsFact
+	}
+	return fact, nil
+}
+
+func init() {
+	engine.AddToRuleset(&DotnetProjectExistsRule, &GlobalJSONExistsRule)
+	engine.AddToRuleset(&DotnetProjectExistsRule, &DotnetExecutableExistsRule)
+}
@@ -0,0 +1,126 @@
+package dotnet
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDotnetProjectExistsRule(t *testing.T) {
+	for _, name := range []string{"App.sln", "App.csproj", "App.fsproj"} {
+		t.Run("DotnetProjectExistsFact is true with "+name, func(t *testing.T) {
+			d, err := os.MkdirTemp("", "test-src")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(d)
+			if err := os.WriteFile(filepath.Join(d, name), []byte{}, 0644); err != nil {
+				t.Fatal(err)
+			}
+			fact, err := DotnetProjectExistsRule(d, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, fact, DotnetProjectExistsFact)
+		})
+	}
+
+	t.Run("DotnetProjectExistsFact is false", func(t *testing.T) {
+		d, err := os.MkdirTemp("", "test-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(d)
+		if err := os.MkdirAll(filepath.Join(d, "src", "App"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(d, "src", "App", "App.csproj"), []byte(consoleProject), 0644); err != nil {
+			t.Fatal(err)
+		}
+		fact, err := DotnetProjectExistsRule(d, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.NotEqual(t, fact, DotnetProjectExistsFact)
+	})
+}
+
+func TestGlobalJSONExistsRule(t *testing.T) {
+	t.Run("GlobalJSONExistsFact is true", func(t *testing.T) {
+		d, err := os.MkdirTemp("", "test-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(d)
+		if err := os.WriteFile(filepath.Join(d, "global.json"), []byte(`{"sdk": {"version": "6.0.100"}}`), 0644); err != nil {
+			t.Fatal(err)
+		}
+		fact, err := GlobalJSONExistsRule(d, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, fact, GlobalJSONExistsFact)
+	})
+
+	t.Run("GlobalJSONExistsFact is false", func(t *testing.T) {
+		d, err := os.MkdirTemp("", "test-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(d)
+		fact, err := GlobalJSONExistsRule(d, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.NotEqual(t, fact, GlobalJSONExistsFact)
+	})
+}
+
+func TestDotnetExecutableExistsRule(t *testing.T) {
+	t.Run("DotnetExecutableExistsFact is true", func(t *testing.T) {
+		d, err := os.MkdirTemp("", "test-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(d)
+		if err := os.WriteFile(filepath.Join(d, "App.sln"), []byte(testSolution), 0644); err != nil {
+			t.Fatal(err)
+		}
+		projects := map[string]string{
+			"src/Api/Api.csproj":               webProject,
+			"src/Core/Core.csproj":             libraryProject,
+			"tests/Api.Tests/Api.Tests.csproj": testProject,
+		}
+		for project, contents := range projects {
+			if err := os.MkdirAll(filepath.Join(d, filepath.Dir(project)), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(d, project), []byte(contents), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		fact, err := DotnetExecutableExistsRule(d, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, fact, DotnetExecutableExistsFact)
+	})
+
+	t.Run("DotnetExecutableExistsFact is false", func(t *testing.T) {
+		d, err := os.MkdirTemp("", "test-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(d)
+		if err := os.WriteFile(filepath.Join(d, "Core.csproj"), []byte(libraryProject), 0644); err != nil {
+			t.Fatal(err)
+		}
+		fact, err := DotnetExecutableExistsRule(d, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.NotEqual(t, fact, DotnetExecutableExistsFact)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"dagger.io/dagger"
+	"github.com/trustacks/trustacks/pkg/actions/javascript"
 	"github.com/trustacks/trustacks/pkg/engine"
 )
 
@@ -13,12 +14,15 @@ var eslintRunAction = &engine.Action{
 	Description: "Lint the source with ESLint.",
 	Image:       func(_ *engine.Config) string { return "node:alpine" },
 	Stage:       engine.CommitStage,
-	Caches:      []string{"/src/node_modules"},
+	Caches:      javascript.Caches,
 	Script: func(container *dagger.Container, _ map[string]interface{}, utils *engine.ActionUtilities) error {
 		container = container.WithExec([]string{"apk", "add", "bash"})
-		container = container.WithExec([]string{"npm", "install"})
-		container = container.WithExec([]string{"npx", "-y", "eslint", "./"})
-		_, err := container.Sync(context.Background())
+		container, manager, err := javascript.InstallDependencies(container)
+		if err != nil {
+			return err
+		}
+		container = container.WithExec(javascript.ExecArgs(manager, "eslint", "./"))
+		_, err = container.Sync(context.Background())
 		return err
 	},
 	AdmissionCriteria: []engine.Fact{ESLintConfigExistsFact},
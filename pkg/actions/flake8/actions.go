@@ -2,41 +2,145 @@ package flake8
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 
 	"dagger.io/dagger"
+	"github.com/pelletier/go-toml/v2"
 	"github.com/trustacks/trustacks/pkg/actions/python"
 	"github.com/trustacks/trustacks/pkg/engine"
 )
 
+// ruffConfigFiles are the ruff configuration files other than the
+// pyproject.toml.
+var ruffConfigFiles = []string{"ruff.toml", ".ruff.toml"}
+
+// ruffConfigured returns true if ruff is configured in the ruff
+// configuration files or in the pyproject.toml [tool.ruff] table.
+func ruffConfigured(entries []string, pyproject []byte) (bool, error) {
+	for _, name := range ruffConfigFiles {
+		if engine.HasEntry(entries, name) {
+			return true, nil
+		}
+	}
+	if pyproject == nil {
+		return false, nil
+	}
+	project := struct {
+		Tool struct {
+			Ruff map[string]interface{} `toml:"ruff"`
+		} `toml:"tool"`
+	}{}
+	if err := toml.Unmarshal(pyproject, &project); err != nil {
+		return false, err
+	}
+	return project.Tool.Ruff != nil, nil
+}
+
+// withReports writes the json and SARIF reports of the findings to the
+// lint report directory.
+func withReports(container *dagger.Container, tool string, findings []finding) (*dagger.Container, error) {
+	report, err := json.MarshalIndent(findings, "", "  ")
+	if err != nil {
+		return container, err
+	}
+	sarif, err := sarifReport(tool, findings)
+	if err != nil {
+		return container, err
+	}
+	return container.
+		WithNewFile(fmt.Sprintf("/tmp/lint/%s.json", tool), dagger.ContainerWithNewFileOpts{Contents: string(report)}).
+		WithNewFile(fmt.Sprintf("/tmp/lint/%s.sarif", tool), dagger.ContainerWithNewFileOpts{Contents: string(sarif)}), nil
+}
+
 var flake8RunAction = &engine.Action{
 	Name:        "flake8Run",
 	DisplayName: "Flake8 Run",
-	Description: "Run the flake8 linter",
-	Image: func(config *engine.Config) string {
-		if config.Python.Version != "" {
-			return fmt.Sprintf("python:%s", config.Python.Version)
-		}
-		return "python"
+	Description: "Lint the python source with flake8, and ruff if it is configured.",
+	Image:       python.Image,
+	Stage:       engine.CommitStage,
+	Caches:      python.Caches,
+	OutputArtifacts: []engine.Artifact{
+		engine.LintReportArtifact,
 	},
-	Stage: engine.CommitStage,
 	Script: func(container *dagger.Container, _ map[string]interface{}, utils *engine.ActionUtilities) error {
 		config := utils.GetConfig()
 		container = container.WithExec([]string{"apt", "update"})
 		container = container.WithExec([]string{"apt", "install", "gcc", "-y"})
 		container = container.WithExec([]string{"apt", "install", "-y", strings.Join(config.Python.Libraries, " ")})
-		container, err := python.InstallPythonDependencies(container)
+		// the source dependencies provide the configured flake8 plugins.
+		container, _, err := python.InstallPythonDependencies(container)
 		if err != nil {
 			return err
 		}
 		if config.Python.DevRequirements != "" {
 			container = container.WithExec([]string{"pip", "install", "-r", config.Python.DevRequirements})
 		}
-		container = container.WithExec([]string{"pip", "install", "pytest"})
-		container = container.WithExec([]string{"pytest"})
-		_, err = container.Stdout(context.Background())
-		return err
+		// the default format is parsed regardless of the configured
+		// flake8 format.
+		container = container.
+			WithExec([]string{"pip", "install", "flake8"}).
+			WithExec([]string{"flake8", "--exit-zero", "--format=default", "--output-file", "/tmp/flake8.txt", "."})
+		output, err := container.File("/tmp/flake8.txt").Contents(context.Background())
+		if err != nil {
+			return err
+		}
+		findings, err := parseFlake8Output(strings.NewReader(output))
+		if err != nil {
+			return err
+		}
+		container, err = withReports(container, "flake8", findings)
+		if err != nil {
+			return err
+		}
+		utils.AddSummary(summaryLines("flake8", findings)...)
+		total := len(findings)
+
+		entries, err := container.Directory("/src").Entries(context.Background())
+		if err != nil {
+			return err
+		}
+		var pyproject []byte
+		if engine.HasEntry(entries, "pyproject.toml") {
+			pyproject, err = engine.ContainerFileReader(container)("pyproject.toml")
+			if err != nil {
+				return err
+			}
+		}
+		ruff, err := ruffConfigured(entries, pyproject)
+		if err != nil {
+			return err
+		}
+		if ruff {
+			container = container.
+				WithExec([]string{"pip", "install", "ruff"}).
+				WithExec([]string{"/bin/sh", "-c", "ruff check --exit-zero --output-format json . > /tmp/ruff.json"})
+			output, err := container.File("/tmp/ruff.json").Contents(context.Background())
+			if err != nil {
+				return err
+			}
+			findings, err := parseRuffOutput([]byte(output), "/src")
+			if err != nil {
+				return err
+			}
+			container, err = withReports(container, "ruff", findings)
+			if err != nil {
+				return err
+			}
+			utils.AddSummary(summaryLines("ruff", findings)...)
+			total += len(findings)
+		}
+		if err := utils.Export(container, engine.LintReportArtifact, "/tmp/lint"); err != nil {
+			return err
+		}
+		if _, err := container.Sync(context.Background()); err != nil {
+			return err
+		}
+		if total > 0 {
+			return fmt.Errorf("the python source has %d lint findings", total)
+		}
+		return nil
 	},
 	AdmissionCriteria: []engine.Fact{Flake8ConfigExistsFact},
 }
@@ -0,0 +1,36 @@
+package flake8
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuffConfigured(t *testing.T) {
+	tests := []struct {
+		name       string
+		entries    []string
+		pyproject  string
+		configured bool
+	}{
+		{"ruff.toml", []string{"ruff.toml"}, "", true},
+		{".ruff.toml", []string{".ruff.toml"}, "", true},
+		{"pyproject.toml", []string{"pyproject.toml"}, "[tool.ruff]\nline-length = 100\n", true},
+		{"pyproject.toml lint table", []string{"pyproject.toml"}, "[tool.ruff.lint]\nselect = [\"E\"]\n", true},
+		{"not configured", []string{"pyproject.toml"}, "[tool.black]\nline-length = 100\n", false},
+		{"no pyproject.toml", []string{"setup.cfg"}, "", false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var pyproject []byte
+			if test.pyproject != "" {
+				pyproject = []byte(test.pyproject)
+			}
+			configured, err := ruffConfigured(test.entries, pyproject)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, test.configured, configured)
+		})
+	}
+}
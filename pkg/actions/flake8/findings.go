@@ -0,0 +1,184 @@
+package flake8
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// flake8LinePattern matches the default flake8 output format
+// (ie. app/main.py:10:1: E302 expected 2 blank lines, found 1).
+var flake8LinePattern = regexp.MustCompile(`^(.+?):(\d+):(\d+): ([A-Z]+[0-9]+) (.*)$`)
+
+// finding is a lint finding of flake8 or ruff.
+type finding struct {
+	Code     string `json:"code"`
+	Filename string `json:"filename"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+	Text     string `json:"text"`
+}
+
+// parseFlake8Output parses the findings from the default flake8 output
+// format.
+func parseFlake8Output(r io.Reader) ([]finding, error) {
+	findings := []finding{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		match := flake8LinePattern.FindStringSubmatch(line)
+		if match == nil {
+			return nil, fmt.Errorf("invalid flake8 output line '%s'", line)
+		}
+		row, err := strconv.Atoi(match[2])
+		if err != nil {
+			return nil, err
+		}
+		column, err := strconv.Atoi(match[3])
+		if err != nil {
+			return nil, err
+		}
+		findings = append(findings, finding{
+			Code:     match[4],
+			Filename: filepath.ToSlash(strings.TrimPrefix(match[1], "./")),
+			Line:     row,
+			Column:   column,
+			Text:     match[5],
+		})
+	}
+	return findings, scanner.Err()
+}
+
+// parseRuffOutput parses the findings from the ruff json output.
+func parseRuffOutput(data []byte, root string) ([]finding, error) {
+	results := []struct {
+		Code     string `json:"code"`
+		Message  string `json:"message"`
+		Filename string `json:"filename"`
+		Location struct {
+			Row    int `json:"row"`
+			Column int `json:"column"`
+		} `json:"location"`
+	}{}
+	if err := json.Unmarshal(data, &results); err != nil {
+		return nil, err
+	}
+	findings := []finding{}
+	for _, result := range results {
+		// ruff reports absolute file paths.
+		filename, err := filepath.Rel(root, result.Filename)
+		if err != nil {
+			filename = result.Filename
+		}
+		findings = append(findings, finding{
+			Code:     result.Code,
+			Filename: filepath.ToSlash(filename),
+			Line:     result.Location.Row,
+			Column:   result.Location.Column,
+			Text:     result.Message,
+		})
+	}
+	return findings, nil
+}
+
+// ruleCount is the number of findings of a rule.
+type ruleCount struct {
+	Code  string
+	Count int
+}
+
+// countRules returns the number of findings of each rule ordered by the
+// most frequent rule.
+func countRules(findings []finding) []ruleCount {
+	counts := map[string]int{}
+	for _, f := range findings {
+		counts[f.Code]++
+	}
+	rules := []ruleCount{}
+	for code, count := range counts {
+		rules = append(rules, ruleCount{code, count})
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		if rules[i].Count != rules[j].Count {
+			return rules[i].Count > rules[j].Count
+		}
+		return rules[i].Code < rules[j].Code
+	})
+	return rules
+}
+
+// summaryLines returns the printable finding counts of the tool.
+func summaryLines(tool string, findings []finding) []string {
+	lines := []string{fmt.Sprintf("%s: %d findings", tool, len(findings))}
+	for _, rule := range countRules(findings) {
+		lines = append(lines, fmt.Sprintf("%s %s: %d", tool, rule.Code, rule.Count))
+	}
+	return lines
+}
+
+// sarifReport returns the findings as a SARIF 2.1.0 log.
+func sarifReport(tool string, findings []finding) ([]byte, error) {
+	type region struct {
+		StartLine   int `json:"startLine"`
+		StartColumn int `json:"startColumn,omitempty"`
+	}
+	type location struct {
+		PhysicalLocation struct {
+			ArtifactLocation struct {
+				URI string `json:"uri"`
+			} `json:"artifactLocation"`
+			Region region `json:"region"`
+		} `json:"physicalLocation"`
+	}
+	type result struct {
+		RuleID  string `json:"ruleId"`
+		Level   string `json:"level"`
+		Message struct {
+			Text string `json:"text"`
+		} `json:"message"`
+		Locations []location `json:"locations"`
+	}
+	type rule struct {
+		ID string `json:"id"`
+	}
+	rules := []rule{}
+	for _, count := range countRules(findings) {
+		rules = append(rules, rule{count.Code})
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].ID < rules[j].ID })
+	results := []result{}
+	for _, f := range findings {
+		r := result{RuleID: f.Code, Level: "warning"}
+		r.Message.Text = f.Text
+		l := location{}
+		l.PhysicalLocation.ArtifactLocation.URI = f.Filename
+		l.PhysicalLocation.Region = region{f.Line, f.Column}
+		r.Locations = []location{l}
+		results = append(results, r)
+	}
+	report := map[string]interface{}{
+		"$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+		"version": "2.1.0",
+		"runs": []interface{}{
+			map[string]interface{}{
+				"tool": map[string]interface{}{
+					"driver": map[string]interface{}{
+						"name":  tool,
+						"rules": rules,
+					},
+				},
+				"results": results,
+			},
+		},
+	}
+	return json.MarshalIndent(report, "", "  ")
+}
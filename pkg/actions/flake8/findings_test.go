@@ -0,0 +1,96 @@
+package flake8
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFlake8Output(t *testing.T) {
+	findings, err := parseFlake8Output(strings.NewReader(`./app/main.py:10:1: E302 expected 2 blank lines, found 1
+./app/main.py:12:80: E501 line too long (88 > 79 characters)
+./app/util.py:1:1: F401 'os' imported but unused
+
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []finding{
+		{"E302", "app/main.py", 10, 1, "expected 2 blank lines, found 1"},
+		{"E501", "app/main.py", 12, 80, "line too long (88 > 79 characters)"},
+		{"F401", "app/util.py", 1, 1, "'os' imported but unused"},
+	}, findings)
+
+	_, err = parseFlake8Output(strings.NewReader("not a finding"))
+	assert.Error(t, err)
+}
+
+func TestParseRuffOutput(t *testing.T) {
+	findings, err := parseRuffOutput([]byte(`[
+  {
+    "code": "F401",
+    "filename": "/src/app/util.py",
+    "location": {"row": 1, "column": 8},
+    "message": "`+"`os`"+` imported but unused"
+  }
+]`), "/src")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []finding{{"F401", "app/util.py", 1, 8, "`os` imported but unused"}}, findings)
+}
+
+func TestSummaryLines(t *testing.T) {
+	findings := []finding{{Code: "F401"}, {Code: "E501"}, {Code: "E501"}, {Code: "E302"}}
+	assert.Equal(t, []string{
+		"flake8: 4 findings",
+		"flake8 E501: 2",
+		"flake8 E302: 1",
+		"flake8 F401: 1",
+	}, summaryLines("flake8", findings))
+	assert.Equal(t, []string{"ruff: 0 findings"}, summaryLines("ruff", nil))
+}
+
+func TestSarifReport(t *testing.T) {
+	data, err := sarifReport("flake8", []finding{{"E501", "app/main.py", 12, 80, "line too long"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	report := struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Tool struct {
+				Driver struct {
+					Name  string `json:"name"`
+					Rules []struct {
+						ID string `json:"id"`
+					} `json:"rules"`
+				} `json:"driver"`
+			} `json:"tool"`
+			Results []struct {
+				RuleID    string `json:"ruleId"`
+				Locations []struct {
+					PhysicalLocation struct {
+						ArtifactLocation struct {
+							URI string `json:"uri"`
+						} `json:"artifactLocation"`
+						Region struct {
+							StartLine int `json:"startLine"`
+						} `json:"region"`
+					} `json:"physicalLocation"`
+				} `json:"locations"`
+			} `json:"results"`
+		} `json:"runs"`
+	}{}
+	if err := json.Unmarshal(data, &report); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "2.1.0", report.Version)
+	assert.Equal(t, "flake8", report.Runs[0].Tool.Driver.Name)
+	assert.Equal(t, "E501", report.Runs[0].Tool.Driver.Rules[0].ID)
+	assert.Equal(t, "E501", report.Runs[0].Results[0].RuleID)
+	assert.Equal(t, "app/main.py", report.Runs[0].Results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	assert.Equal(t, 12, report.Runs[0].Results[0].Locations[0].PhysicalLocation.Region.StartLine)
+}
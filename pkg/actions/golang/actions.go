@@ -3,12 +3,29 @@ package golang
 import (
 	"context"
 	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
 
 	"dagger.io/dagger"
 	"github.com/trustacks/trustacks/pkg/engine"
 )
 
-const imageName = "golang"
+const (
+	imageName               = "golang"
+	gocoverCoberturaVersion = "v1.2.0"
+)
+
+// mergeCoverageProfilesScript merges the module coverage profiles into a
+// single profile with the mode line of the first profile.
+const mergeCoverageProfilesScript = `
+set -e
+profiles=$(ls /tmp/coverage/*.out)
+head -n 1 $(echo "$profiles" | head -n 1) > coverage.out
+for profile in $profiles; do
+  tail -n +2 "$profile" >> coverage.out
+done
+`
 
 var golangBuild = &engine.Action{
 	Name:        "golangBuild",
@@ -21,7 +38,7 @@ var golangBuild = &engine.Action{
 		engine.BuildArtifact,
 	},
 	Script: func(container *dagger.Container, _ map[string]interface{}, utils *engine.ActionUtilities) error {
-		entries, err := container.Directory("./cmd").Entries(context.Background())
+		modules, err := ContainerModules(container)
 		if err != nil {
 			return err
 		}
@@ -29,16 +46,58 @@ var golangBuild = &engine.Action{
 		if version != "" {
 			container = container.WithEnvVariable("VERSION", version)
 		}
-		for _, entry := range entries {
-			container = container.WithExec([]string{
-				"go",
-				"build",
-				"-ldflags",
-				utils.GetConfig().Golang.LDFlags,
-				"-o",
-				fmt.Sprintf(".build/%s", entry),
-				fmt.Sprintf("./cmd/%s", entry),
-			})
+		config := utils.GetConfig()
+		targets, err := buildTargets(config)
+		if err != nil {
+			return err
+		}
+		commands := map[string]string{}
+		for _, module := range modules {
+			ok, err := moduleHasCmd(container, module)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				continue
+			}
+			entries, err := container.Directory(filepath.Join(module, "cmd")).Entries(context.Background())
+			if err != nil {
+				return err
+			}
+			for _, entry := range entries {
+				if other, ok := commands[entry]; ok {
+					return fmt.Errorf("command '%s' exists in modules '%s' and '%s'", entry, other, module)
+				}
+				commands[entry] = module
+			}
+			moduleContainer := container.Pipeline(module).WithWorkdir(filepath.Join("/src", module))
+			if len(targets) == 0 {
+				for _, entry := range entries {
+					moduleContainer = moduleContainer.WithExec(buildArgs(entry, fmt.Sprintf("/src/.build/%s", entry), nil, config.Golang.LDFlags))
+				}
+			}
+			// cross compile each command for the build matrix targets.
+			for _, target := range targets {
+				targetContainer := moduleContainer
+				env := targetEnv(target)
+				names := make([]string, 0, len(env))
+				for name := range env {
+					names = append(names, name)
+				}
+				sort.Strings(names)
+				for _, name := range names {
+					targetContainer = targetContainer.WithEnvVariable(name, env[name])
+				}
+				for _, entry := range entries {
+					output := fmt.Sprintf("/src/.build/%s", targetBinary(entry, target))
+					targetContainer = targetContainer.WithExec(buildArgs(entry, output, target.Tags, config.Golang.LDFlags))
+				}
+				moduleContainer = moduleContainer.WithDirectory("/src/.build", targetContainer.Directory("/src/.build"))
+			}
+			container = container.WithDirectory(".build", moduleContainer.Directory("/src/.build"))
+		}
+		if len(targets) > 0 {
+			container = container.WithExec([]string{"/bin/sh", "-c", "cd .build && sha256sum * > checksums.txt"})
 		}
 		if err := utils.Export(container, engine.BuildArtifact, ".build"); err != nil {
 			return err
@@ -63,11 +122,32 @@ var golangTest = &engine.Action{
 		engine.CoverageArtifact,
 	},
 	Script: func(container *dagger.Container, _ map[string]interface{}, utils *engine.ActionUtilities) error {
-		container = container.WithExec([]string{"go", "test", "./...", "-v", "-short", "-coverprofile", "coverage.out"})
+		modules, err := ContainerModules(container)
+		if err != nil {
+			return err
+		}
+		profiles := []string{}
+		err = RunModules(modules, func(module string) error {
+			profile := fmt.Sprintf("/tmp/coverage/%d.out", len(profiles))
+			moduleContainer := container.Pipeline(module).
+				WithWorkdir(filepath.Join("/src", module)).
+				WithExec([]string{"go", "test", "./...", "-v", "-short", "-coverprofile", "/tmp/coverage.out"})
+			if _, err := moduleContainer.Sync(context.Background()); err != nil {
+				return err
+			}
+			container = container.WithFile(profile, moduleContainer.File("/tmp/coverage.out"))
+			profiles = append(profiles, profile)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		// merge the module coverage profiles.
+		container = container.WithExec([]string{"/bin/sh", "-c", mergeCoverageProfilesScript})
 		if err := utils.Export(container, engine.CoverageArtifact, "coverage.out"); err != nil {
 			return err
 		}
-		_, err := container.Sync(context.Background())
+		_, err = container.Sync(context.Background())
 		return err
 	},
 	AdmissionCriteria: []engine.Fact{GolangTestsExistsFact},
@@ -87,13 +167,64 @@ var golangIntegrationTest = &engine.Action{
 		}
 		defer stop()
 		container = utils.WithDockerCLI(engine.DockerCLIOnDebian, container)
-		container = container.WithExec([]string{"go", "test", "./...", "-v", "-run", "Integration"})
-		_, err = container.Sync(context.Background())
-		return err
+		modules, err := ContainerModules(container)
+		if err != nil {
+			return err
+		}
+		return RunModules(modules, func(module string) error {
+			_, err := container.Pipeline(module).
+				WithWorkdir(filepath.Join("/src", module)).
+				WithExec([]string{"go", "test", "./...", "-v", "-run", "Integration"}).
+				Sync(context.Background())
+			return err
+		})
 	},
 	AdmissionCriteria: []engine.Fact{GolangIntegrationTestsExistsFact},
 }
 
+var golangCoverage = &engine.Action{
+	Name:        "golangCoverage",
+	DisplayName: "Golang Coverage",
+	Description: "Report the test coverage and check the coverage threshold.",
+	Image:       func(_ *engine.Config) string { return imageName },
+	Stage:       engine.CommitStage,
+	Caches:      []string{"/go/pkg/mod"},
+	InputArtifacts: []engine.Artifact{
+		engine.CoverageArtifact,
+	},
+	OutputArtifacts: []engine.Artifact{
+		engine.CoverageReportArtifact,
+	},
+	Script: func(container *dagger.Container, _ map[string]interface{}, utils *engine.ActionUtilities) error {
+		container, coverageMount, err := utils.Mount(container, engine.CoverageArtifact)
+		if err != nil {
+			return err
+		}
+		profile, err := container.File(coverageMount.Path("coverage.out")).Contents(context.Background())
+		if err != nil {
+			return err
+		}
+		summary, err := parseCoverProfile(strings.NewReader(profile))
+		if err != nil {
+			return err
+		}
+		utils.AddSummary(summary.Lines()...)
+		container = container.WithExec([]string{"mkdir", "-p", "/tmp/coverage"})
+		container = container.WithExec([]string{"cp", coverageMount.Path("coverage.out"), "/tmp/coverage/coverage.out"})
+		container = container.WithExec([]string{"go", "install", fmt.Sprintf("github.com/boumenot/gocover-cobertura@%s", gocoverCoberturaVersion)})
+		container = container.WithExec([]string{"/bin/sh", "-c", "gocover-cobertura < /tmp/coverage/coverage.out > /tmp/coverage/coverage.xml"})
+		container = container.WithExec([]string{"go", "tool", "cover", "-html=/tmp/coverage/coverage.out", "-o", "/tmp/coverage/coverage.html"})
+		if err := utils.Export(container, engine.CoverageReportArtifact, "/tmp/coverage"); err != nil {
+			return err
+		}
+		if _, err := container.Sync(context.Background()); err != nil {
+			return err
+		}
+		return checkCoverageThreshold(summary, utils.GetConfig().Golang.CoverageThreshold)
+	},
+	AdmissionCriteria: []engine.Fact{GolangTestsExistsFact},
+}
+
 func init() {
 	engine.RegisterPatternMatches([]engine.PatternMatch{
 		{
@@ -104,5 +235,6 @@ func init() {
 	})
 	engine.RegisterAction(golangBuild)
 	engine.RegisterAction(golangTest)
+	engine.RegisterAction(golangCoverage)
 	engine.RegisterAction(golangIntegrationTest)
 }
@@ -0,0 +1,115 @@
+package golang
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// coverageBlock is the statement count and execution count of a
+// coverage profile block.
+type coverageBlock struct {
+	statements int
+	count      int
+}
+
+// packageCoverage is the statement coverage of a package.
+type packageCoverage struct {
+	Name       string
+	Statements int
+	Covered    int
+}
+
+// Percent returns the percentage of covered statements.
+func (c packageCoverage) Percent() float64 {
+	if c.Statements == 0 {
+		return 0
+	}
+	return float64(c.Covered) / float64(c.Statements) * 100 //nolint:gomnd
+}
+
+// coverageSummary is the total and per package statement coverage.
+type coverageSummary struct {
+	Total    packageCoverage
+	Packages []packageCoverage
+}
+
+// Lines returns the printable summary lines.
+func (s coverageSummary) Lines() []string {
+	lines := []string{fmt.Sprintf("total: %.1f%%", s.Total.Percent())}
+	for _, pkg := range s.Packages {
+		lines = append(lines, fmt.Sprintf("%s: %.1f%%", pkg.Name, pkg.Percent()))
+	}
+	return lines
+}
+
+// parseCoverProfile computes the coverage summary from a go coverage
+// profile. Blocks that appear in more than one profile of a merged
+// profile are counted once.
+func parseCoverProfile(r io.Reader) (coverageSummary, error) {
+	blocks := map[string]coverageBlock{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "mode:") {
+			continue
+		}
+		// ie. example.com/app/pkg/file.go:10.2,12.16 2 1
+		fields := strings.Fields(line)
+		if len(fields) != 3 { //nolint:gomnd
+			return coverageSummary{}, fmt.Errorf("invalid coverage profile line '%s'", line)
+		}
+		statements, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return coverageSummary{}, err
+		}
+		count, err := strconv.Atoi(fields[2])
+		if err != nil {
+			return coverageSummary{}, err
+		}
+		block := blocks[fields[0]]
+		block.statements = statements
+		if count > block.count {
+			block.count = count
+		}
+		blocks[fields[0]] = block
+	}
+	if err := scanner.Err(); err != nil {
+		return coverageSummary{}, err
+	}
+	packages := map[string]*packageCoverage{}
+	summary := coverageSummary{Total: packageCoverage{Name: "total"}}
+	for key, block := range blocks {
+		file, _, _ := strings.Cut(key, ":")
+		name := path.Dir(file)
+		if _, ok := packages[name]; !ok {
+			packages[name] = &packageCoverage{Name: name}
+		}
+		packages[name].Statements += block.statements
+		summary.Total.Statements += block.statements
+		if block.count > 0 {
+			packages[name].Covered += block.statements
+			summary.Total.Covered += block.statements
+		}
+	}
+	for _, pkg := range packages {
+		summary.Packages = append(summary.Packages, *pkg)
+	}
+	sort.Slice(summary.Packages, func(i, j int) bool {
+		return summary.Packages[i].Name < summary.Packages[j].Name
+	})
+	return summary, nil
+}
+
+// checkCoverageThreshold returns an error if the total coverage is below
+// the threshold percentage.
+func checkCoverageThreshold(summary coverageSummary, threshold float64) error {
+	if threshold > 0 && summary.Total.Percent() < threshold {
+		return fmt.Errorf("total coverage %.1f%% is below the %.1f%% threshold", summary.Total.Percent(), threshold)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package golang
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testCoverProfile = `mode: set
+example.com/app/pkg/a/a.go:3.14,5.2 2 1
+example.com/app/pkg/a/a.go:7.14,9.2 2 0
+example.com/app/pkg/b/b.go:3.14,5.2 4 1
+mode: set
+example.com/app/pkg/a/a.go:7.14,9.2 2 1
+example.com/app/pkg/c/c.go:3.14,5.2 2 0
+`
+
+func TestParseCoverProfile(t *testing.T) {
+	summary, err := parseCoverProfile(strings.NewReader(testCoverProfile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, packageCoverage{Name: "total", Statements: 10, Covered: 8}, summary.Total)
+	assert.Equal(t, []packageCoverage{
+		{Name: "example.com/app/pkg/a", Statements: 4, Covered: 4},
+		{Name: "example.com/app/pkg/b", Statements: 4, Covered: 4},
+		{Name: "example.com/app/pkg/c", Statements: 2, Covered: 0},
+	}, summary.Packages)
+	assert.Equal(t, []string{
+		"total: 80.0%",
+		"example.com/app/pkg/a: 100.0%",
+		"example.com/app/pkg/b: 100.0%",
+		"example.com/app/pkg/c: 0.0%",
+	}, summary.Lines())
+
+	_, err = parseCoverProfile(strings.NewReader("example.com/app/a.go:3.14,5.2 2\n"))
+	assert.Error(t, err)
+}
+
+func TestCheckCoverageThreshold(t *testing.T) {
+	summary := coverageSummary{Total: packageCoverage{Name: "total", Statements: 10, Covered: 8}}
+	assert.NoError(t, checkCoverageThreshold(summary, 0))
+	assert.NoError(t, checkCoverageThreshold(summary, 80))
+	assert.EqualError(t, checkCoverageThreshold(summary, 85.5), "total coverage 80.0% is below the 85.5% threshold")
+}
@@ -0,0 +1,163 @@
+package golang
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"dagger.io/dagger"
+	"github.com/trustacks/trustacks/pkg/engine"
+)
+
+// moduleExclusions are the directories that are not searched for go
+// modules.
+var moduleExclusions = []string{"vendor", "testdata", "node_modules"}
+
+// parseGoWork returns the module directories of the go.work use
+// directives.
+func parseGoWork(contents string) []string {
+	dirs := []string{}
+	inBlock := false
+	scanner := bufio.NewScanner(strings.NewReader(contents))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = line[:i]
+		}
+		line = strings.TrimSpace(line)
+		switch {
+		case inBlock && line == ")":
+			inBlock = false
+		case inBlock && line != "":
+			dirs = append(dirs, strings.Trim(line, `"`))
+		case line == "use (":
+			inBlock = true
+		case strings.HasPrefix(line, "use "):
+			dirs = append(dirs, strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "use ")), `"`))
+		}
+	}
+	return dirs
+}
+
+// moduleDirs returns the sorted module directories relative to the
+// source root. The go.work use directives take precedence over the
+// discovered go.mod files.
+func moduleDirs(gomods []string, gowork string) []string {
+	dirs := []string{}
+	if gowork != "" {
+		dirs = parseGoWork(gowork)
+	} else {
+		for _, path := range gomods {
+			dirs = append(dirs, filepath.Dir(path))
+		}
+	}
+	seen := map[string]bool{}
+	modules := []string{}
+	for _, dir := range dirs {
+		dir = filepath.Clean(dir)
+		if !seen[dir] {
+			seen[dir] = true
+			modules = append(modules, dir)
+		}
+	}
+	sort.Strings(modules)
+	return modules
+}
+
+// isExcludedModulePath returns true if the path is inside of a directory
+// that is not searched for go modules.
+func isExcludedModulePath(path string) bool {
+	for _, part := range strings.Split(filepath.ToSlash(path), "/") {
+		for _, exclusion := range moduleExclusions {
+			if part == exclusion {
+				return true
+			}
+		}
+		if strings.HasPrefix(part, ".") && part != "." {
+			return true
+		}
+	}
+	return false
+}
+
+// sourceModules returns the go module directories in the source.
+func sourceModules(source string) ([]string, error) {
+	gowork, err := os.ReadFile(filepath.Join(source, "go.work"))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	gomods := []string{}
+	if err := filepath.WalkDir(source, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(source, path)
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && isExcludedModulePath(rel) {
+			return filepath.SkipDir
+		}
+		if !d.IsDir() && d.Name() == "go.mod" {
+			gomods = append(gomods, rel)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return moduleDirs(gomods, string(gowork)), nil
+}
+
+// ContainerModules returns the go module directories in the action
+// container source.
+func ContainerModules(container *dagger.Container) ([]string, error) {
+	entries, err := container.Directory(".").Entries(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	gowork := ""
+	if engine.HasEntry(entries, "go.work") {
+		gowork, err = container.File("go.work").Contents(context.Background())
+		if err != nil {
+			return nil, err
+		}
+	}
+	stdout, err := container.WithExec([]string{"find", ".", "-name", "go.mod"}).Stdout(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	gomods := []string{}
+	for _, path := range strings.Fields(stdout) {
+		if !isExcludedModulePath(filepath.Dir(path)) {
+			gomods = append(gomods, path)
+		}
+	}
+	return moduleDirs(gomods, gowork), nil
+}
+
+// moduleHasCmd returns true if the module contains a cmd directory.
+func moduleHasCmd(container *dagger.Container, module string) (bool, error) {
+	entries, err := container.Directory(module).Entries(context.Background())
+	if err != nil {
+		return false, err
+	}
+	return engine.HasEntry(entries, "cmd"), nil
+}
+
+// RunModules runs the module function for each module and reports the
+// result of every module.
+func RunModules(modules []string, run func(module string) error) error {
+	errs := []error{}
+	for _, module := range modules {
+		if err := run(module); err != nil {
+			errs = append(errs, fmt.Errorf("module '%s': %w", module, err))
+		}
+	}
+	return errors.Join(errs...)
+}
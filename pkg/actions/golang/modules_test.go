@@ -0,0 +1,73 @@
+package golang
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseGoWork(t *testing.T) {
+	gowork := `go 1.21
+
+use ./tools // build tools
+
+use (
+	.
+	./services/api
+	"./services/worker"
+)
+`
+	assert.Equal(t, []string{"./tools", ".", "./services/api", "./services/worker"}, parseGoWork(gowork))
+}
+
+func TestModuleDirs(t *testing.T) {
+	gomods := []string{"services/api/go.mod", "go.mod", "./services/api/go.mod"}
+	assert.Equal(t, []string{".", "services/api"}, moduleDirs(gomods, ""))
+	assert.Equal(t, []string{"libs/a"}, moduleDirs(gomods, "go 1.21\n\nuse ./libs/a\n"))
+}
+
+func TestSourceModules(t *testing.T) {
+	d, err := os.MkdirTemp("", "test-src")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(d)
+	for _, dir := range []string{".", "services/api", "vendor/example.com/lib", "testdata/mod", ".git/mod"} {
+		if err := os.MkdirAll(filepath.Join(d, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(d, dir, "go.mod"), []byte(""), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	modules, err := sourceModules(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{".", "services/api"}, modules)
+
+	if err := os.WriteFile(filepath.Join(d, "go.work"), []byte("go 1.21\n\nuse ./services/api\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	modules, err = sourceModules(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"services/api"}, modules)
+}
+
+func TestRunModules(t *testing.T) {
+	ran := []string{}
+	err := RunModules([]string{".", "a", "b"}, func(module string) error {
+		ran = append(ran, module)
+		if module == "a" {
+			return errors.New("tests failed")
+		}
+		return nil
+	})
+	assert.Equal(t, []string{".", "a", "b"}, ran)
+	assert.EqualError(t, err, "module 'a': tests failed")
+}
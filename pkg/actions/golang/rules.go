@@ -16,14 +16,17 @@ var (
 	GolangCmdExistsFact              = engine.NewFact()
 )
 
+// GoModExistsRule is true if the source contains a go module or a
+// go.work workspace.
 var GoModExistsRule engine.Rule = func(source string, _ engine.Collector, _ mapset.Set[engine.Fact]) (engine.Fact, error) {
 	var fact = engine.NilFact
-	if _, err := os.Stat(filepath.Join(source, "go.mod")); os.IsNotExist(err) {
-		return fact, nil
-	} else if err != nil {
+	modules, err := sourceModules(source)
+	if err != nil {
 		return fact, err
 	}
-	fact = GoModExistsFact
+	if len(modules) > 0 {
+		fact = GoModExistsFact
+	}
 	return fact, nil
 }
 
@@ -50,18 +53,27 @@ var GolangIntegrationTestExistsRule engine.Rule = func(source string, collector
 	return fact, nil
 }
 
+// GolangCmdExistsRule is true if the source root or any of the source
+// modules contains a cmd directory.
 var GolangCmdExistsRule engine.Rule = func(source string, _ engine.Collector, _ mapset.Set[engine.Fact]) (engine.Fact, error) {
 	var fact = engine.NilFact
-	stat, err := os.Stat(filepath.Join(source, "./cmd"))
-	if os.IsNotExist(err) {
-		return fact, nil
-	} else if err != nil {
+	modules, err := sourceModules(source)
+	if err != nil {
 		return fact, err
 	}
-	if !stat.IsDir() {
-		return fact, nil
+	// the root cmd directory is always checked.
+	for _, module := range append([]string{"."}, modules...) {
+		stat, err := os.Stat(filepath.Join(source, module, "cmd"))
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return fact, err
+		}
+		if stat.IsDir() {
+			fact = GolangCmdExistsFact
+			break
+		}
 	}
-	fact = GolangCmdExistsFact
 	return fact, nil
 }
 
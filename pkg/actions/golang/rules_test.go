@@ -33,6 +33,41 @@ func TestGoModExistsRule(t *testing.T) {
 		assert.Equal(t, fact, GoModExistsFact)
 	})
 
+	t.Run("GoModExistsFact is true for nested modules", func(t *testing.T) {
+		d, err := os.MkdirTemp("", "test-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(d)
+		if err := os.MkdirAll(filepath.Join(d, "services", "api"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(d, "services", "api", "go.mod"), []byte(``), 0744); err != nil {
+			t.Fatal(err)
+		}
+		fact, err := GoModExistsRule(d, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, fact, GoModExistsFact)
+	})
+
+	t.Run("GoModExistsFact is true for workspaces", func(t *testing.T) {
+		d, err := os.MkdirTemp("", "test-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(d)
+		if err := os.WriteFile(filepath.Join(d, "go.work"), []byte("go 1.21\n\nuse ./api\n"), 0744); err != nil {
+			t.Fatal(err)
+		}
+		fact, err := GoModExistsRule(d, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, fact, GoModExistsFact)
+	})
+
 	t.Run("GoModExistsFact is false", func(t *testing.T) {
 		d, err := os.MkdirTemp("", "test-src")
 		if err != nil {
@@ -138,6 +173,25 @@ func TestGolangCmdExistsRule(t *testing.T) {
 		assert.Equal(t, fact, GolangCmdExistsFact)
 	})
 
+	t.Run("GolangCmdExistsFact is true for nested modules", func(t *testing.T) {
+		d, err := os.MkdirTemp("", "test-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(d)
+		if err := os.MkdirAll(filepath.Join(d, "services", "api", "cmd"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(d, "services", "api", "go.mod"), []byte(``), 0744); err != nil {
+			t.Fatal(err)
+		}
+		fact, err := GolangCmdExistsRule(d, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, fact, GolangCmdExistsFact)
+	})
+
 	t.Run("GolangCmdExistsFact is false if cmd is not a directory", func(t *testing.T) {
 		d, err := os.MkdirTemp("", "test-src")
 		if err != nil {
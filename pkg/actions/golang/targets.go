@@ -0,0 +1,80 @@
+package golang
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/trustacks/trustacks/pkg/engine"
+)
+
+// buildTargets returns the cross compilation targets from the golang
+// build matrix, or the targets for the container platforms if the
+// matrix is not configured.
+func buildTargets(config *engine.Config) ([]engine.ConfigGolangTarget, error) {
+	if len(config.Golang.Targets) > 0 {
+		for _, target := range config.Golang.Targets {
+			if target.GOOS == "" || target.GOARCH == "" {
+				return nil, fmt.Errorf("golang build targets require goos and goarch")
+			}
+		}
+		return config.Golang.Targets, nil
+	}
+	targets := []engine.ConfigGolangTarget{}
+	for _, platform := range config.Container.Platforms {
+		parts := strings.Split(platform, "/")
+		if len(parts) < 2 || len(parts) > 3 {
+			return nil, fmt.Errorf("invalid platform '%s'", platform)
+		}
+		target := engine.ConfigGolangTarget{GOOS: parts[0], GOARCH: parts[1]}
+		// platforms with variants such as linux/arm/v7.
+		if len(parts) == 3 && parts[1] == "arm" {
+			target.GOARM = strings.TrimPrefix(parts[2], "v")
+		}
+		targets = append(targets, target)
+	}
+	return targets, nil
+}
+
+// targetPlatform returns the container platform of the target.
+func targetPlatform(target engine.ConfigGolangTarget) string {
+	platform := fmt.Sprintf("%s/%s", target.GOOS, target.GOARCH)
+	if target.GOARM != "" {
+		platform = fmt.Sprintf("%s/v%s", platform, target.GOARM)
+	}
+	return platform
+}
+
+// targetBinary returns the target binary name for the command
+// (ie. app_linux_arm64).
+func targetBinary(entry string, target engine.ConfigGolangTarget) string {
+	name := fmt.Sprintf("%s_%s", entry, engine.PlatformPath(targetPlatform(target)))
+	if target.GOOS == "windows" {
+		name += ".exe"
+	}
+	return name
+}
+
+// targetEnv returns the go environment variables for the target.
+func targetEnv(target engine.ConfigGolangTarget) map[string]string {
+	env := map[string]string{
+		"GOOS":        target.GOOS,
+		"GOARCH":      target.GOARCH,
+		"CGO_ENABLED": "0",
+	}
+	if target.CGO {
+		env["CGO_ENABLED"] = "1"
+	}
+	if target.GOARM != "" {
+		env["GOARM"] = target.GOARM
+	}
+	return env
+}
+
+// buildArgs returns the go build arguments for the command.
+func buildArgs(entry, output string, tags []string, ldflags string) []string {
+	args := []string{"go", "build", "-ldflags", ldflags}
+	if len(tags) > 0 {
+		args = append(args, "-tags", strings.Join(tags, ","))
+	}
+	return append(args, "-o", output, fmt.Sprintf("./cmd/%s", entry))
+}
@@ -0,0 +1,77 @@
+package golang
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustacks/trustacks/pkg/engine"
+)
+
+func TestBuildTargets(t *testing.T) {
+	t.Run("matrix targets", func(t *testing.T) {
+		config := &engine.Config{}
+		config.Golang.Targets = []engine.ConfigGolangTarget{{GOOS: "linux", GOARCH: "amd64"}}
+		config.Container.Platforms = []string{"linux/arm64"}
+		targets, err := buildTargets(config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, config.Golang.Targets, targets)
+	})
+	t.Run("matrix targets without container platforms", func(t *testing.T) {
+		config := &engine.Config{}
+		config.Golang.Targets = []engine.ConfigGolangTarget{{GOOS: "linux", GOARCH: "amd64"}}
+		targets, err := buildTargets(config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// the binaries are suffixed and renamed for the engine platform
+		// by the container build.
+		assert.Equal(t, []string{"app_linux_amd64"}, []string{targetBinary("app", targets[0])})
+	})
+	t.Run("container platform targets", func(t *testing.T) {
+		config := &engine.Config{}
+		config.Container.Platforms = []string{"linux/amd64", "linux/arm/v7"}
+		targets, err := buildTargets(config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, []engine.ConfigGolangTarget{
+			{GOOS: "linux", GOARCH: "amd64"},
+			{GOOS: "linux", GOARCH: "arm", GOARM: "7"},
+		}, targets)
+	})
+	t.Run("no targets", func(t *testing.T) {
+		targets, err := buildTargets(&engine.Config{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Empty(t, targets)
+	})
+	t.Run("invalid targets", func(t *testing.T) {
+		config := &engine.Config{}
+		config.Golang.Targets = []engine.ConfigGolangTarget{{GOOS: "linux"}}
+		_, err := buildTargets(config)
+		assert.Error(t, err)
+		config = &engine.Config{}
+		config.Container.Platforms = []string{"linux"}
+		_, err = buildTargets(config)
+		assert.Error(t, err)
+	})
+}
+
+func TestTargetBinary(t *testing.T) {
+	assert.Equal(t, "app_linux_arm64", targetBinary("app", engine.ConfigGolangTarget{GOOS: "linux", GOARCH: "arm64"}))
+	assert.Equal(t, "app_linux_arm_v7", targetBinary("app", engine.ConfigGolangTarget{GOOS: "linux", GOARCH: "arm", GOARM: "7"}))
+	assert.Equal(t, "app_windows_amd64.exe", targetBinary("app", engine.ConfigGolangTarget{GOOS: "windows", GOARCH: "amd64"}))
+}
+
+func TestTargetEnv(t *testing.T) {
+	assert.Equal(t, map[string]string{"GOOS": "linux", "GOARCH": "arm", "GOARM": "7", "CGO_ENABLED": "0"}, targetEnv(engine.ConfigGolangTarget{GOOS: "linux", GOARCH: "arm", GOARM: "7"}))
+	assert.Equal(t, "1", targetEnv(engine.ConfigGolangTarget{GOOS: "linux", GOARCH: "amd64", CGO: true})["CGO_ENABLED"])
+}
+
+func TestBuildArgs(t *testing.T) {
+	assert.Equal(t, []string{"go", "build", "-ldflags", "-s", "-o", ".build/app", "./cmd/app"}, buildArgs("app", ".build/app", nil, "-s"))
+	assert.Equal(t, []string{"go", "build", "-ldflags", "", "-tags", "netgo,osusergo", "-o", "out", "./cmd/app"}, buildArgs("app", "out", []string{"netgo", "osusergo"}, ""))
+}
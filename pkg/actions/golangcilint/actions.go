@@ -3,8 +3,10 @@ package golangcilint
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 
 	"dagger.io/dagger"
+	"github.com/trustacks/trustacks/pkg/actions/golang"
 	"github.com/trustacks/trustacks/pkg/engine"
 )
 
@@ -24,9 +26,17 @@ var golangCILintRun = &engine.Action{
 			"-c",
 			fmt.Sprintf("curl -sSfL https://raw.githubusercontent.com/golangci/golangci-lint/master/install.sh | sh -s -- -b $(go env GOPATH)/bin %s", golangciLintVersion),
 		})
-		container = container.WithExec([]string{"golangci-lint", "run"})
-		_, err := container.Sync(context.Background())
-		return err
+		modules, err := golang.ContainerModules(container)
+		if err != nil {
+			return err
+		}
+		return golang.RunModules(modules, func(module string) error {
+			_, err := container.Pipeline(module).
+				WithWorkdir(filepath.Join("/src", module)).
+				WithExec([]string{"golangci-lint", "run"}).
+				Sync(context.Background())
+			return err
+		})
 	},
 	AdmissionCriteria: []engine.Fact{GolangCILintConfigExistsFact},
 }
@@ -2,12 +2,58 @@ package goreleaser
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"dagger.io/dagger"
 	"github.com/mitchellh/mapstructure"
 	"github.com/trustacks/trustacks/pkg/engine"
+	"gopkg.in/yaml.v2"
 )
 
+const defaultDistDir = "dist"
+
+// distDir returns the goreleaser dist directory from the configuration.
+func distDir(config string) (string, error) {
+	values := struct {
+		Dist string `yaml:"dist"`
+	}{}
+	if err := yaml.Unmarshal([]byte(config), &values); err != nil {
+		return "", err
+	}
+	if values.Dist == "" {
+		return defaultDistDir, nil
+	}
+	return values.Dist, nil
+}
+
+// releaseArgs returns the goreleaser release command. Snapshot releases
+// are built without publishing.
+func releaseArgs(snapshot bool) []string {
+	args := []string{"goreleaser", "release", "--clean"}
+	if snapshot {
+		args = append(args, "--snapshot")
+	}
+	return args
+}
+
+// releaseConfig returns the contents of the goreleaser configuration in
+// the container source.
+func releaseConfig(container *dagger.Container) (string, error) {
+	entries, err := container.Directory(".").Entries(context.Background())
+	if err != nil {
+		return "", err
+	}
+	for _, name := range configFiles {
+		for _, entry := range entries {
+			if entry == name {
+				return container.File(name).Contents(context.Background())
+			}
+		}
+	}
+	return "", fmt.Errorf("goreleaser configuration does not exist")
+}
+
 var goreleaserRelease = &engine.Action{
 	Name:        "goreleaserRelease",
 	DisplayName: "Goreleaser Release",
@@ -15,17 +61,43 @@ var goreleaserRelease = &engine.Action{
 	Image:       func(_ *engine.Config) string { return "golang" },
 	Stage:       engine.ReleaseStage,
 	Caches:      []string{"/go/pkg/mod"},
-	Script: func(container *dagger.Container, inputs map[string]interface{}, _ *engine.ActionUtilities) error {
+	OutputArtifacts: []engine.Artifact{
+		engine.ReleaseArtifact,
+	},
+	Script: func(container *dagger.Container, inputs map[string]interface{}, utils *engine.ActionUtilities) error {
 		args := struct {
 			GITHUB_TOKEN string //nolint:revive,stylecheck
 		}{}
 		if err := mapstructure.Decode(inputs, &args); err != nil {
 			return err
 		}
-		container = container.WithExec([]string{"/bin/sh", "-c", "echo 'deb [trusted=yes] https://repo.goreleaser.com/apt/ /' | sudo tee /etc/apt/sources.list.d/goreleaser.list"})
-		container = container.WithExec([]string{"apt", "update"})
-		container = container.WithExec([]string{"apt", "install", "git", "goreleaser"})
-		_, err := container.Sync(context.Background())
+		config, err := releaseConfig(container)
+		if err != nil {
+			return err
+		}
+		dist, err := distDir(config)
+		if err != nil {
+			return err
+		}
+		container = container.WithExec([]string{"/bin/sh", "-c", "echo 'deb [trusted=yes] https://repo.goreleaser.com/apt/ /' > /etc/apt/sources.list.d/goreleaser.list"})
+		container = container.WithExec([]string{"apt-get", "update"})
+		container = container.WithExec([]string{"apt-get", "install", "-y", "git", "goreleaser"})
+		container = container.WithExec([]string{"git", "config", "--global", "--add", "safe.directory", "*"})
+		// only tagged commits are published. other commits and prerelease
+		// runs build a snapshot.
+		tags, err := container.WithExec([]string{"git", "tag", "--points-at", "HEAD"}).Stdout(context.Background())
+		if err != nil {
+			return err
+		}
+		snapshot := utils.IsPrerelease() || strings.TrimSpace(tags) == ""
+		if !snapshot {
+			container = container.WithSecretVariable("GITHUB_TOKEN", utils.SetSecret("GITHUB_TOKEN", args.GITHUB_TOKEN))
+		}
+		container = container.WithExec(releaseArgs(snapshot))
+		if err := utils.Export(container, engine.ReleaseArtifact, dist); err != nil {
+			return err
+		}
+		_, err = container.Sync(context.Background())
 		return err
 	},
 	Inputs: []engine.InputField{
@@ -0,0 +1,25 @@
+package goreleaser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDistDir(t *testing.T) {
+	dist, err := distDir("project_name: app\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "dist", dist)
+	dist, err = distDir("project_name: app\ndist: out/release\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "out/release", dist)
+}
+
+func TestReleaseArgs(t *testing.T) {
+	assert.Equal(t, []string{"goreleaser", "release", "--clean"}, releaseArgs(false))
+	assert.Equal(t, []string{"goreleaser", "release", "--clean", "--snapshot"}, releaseArgs(true))
+}
@@ -13,10 +13,16 @@ var (
 	GoreleaserConfigExistsFact = engine.NewFact()
 )
 
+// configFiles are the supported goreleaser configuration file names.
+var configFiles = []string{".goreleaser.yaml", ".goreleaser.yml"}
+
 var GoreleaserConfigExistsRule engine.Rule = func(source string, _ engine.Collector, _ mapset.Set[engine.Fact]) (engine.Fact, error) {
 	var fact = engine.NilFact
-	if _, err := os.Stat(filepath.Join(source, ".goreleaser.yaml")); !os.IsNotExist(err) {
-		fact = GoreleaserConfigExistsFact
+	for _, name := range configFiles {
+		if _, err := os.Stat(filepath.Join(source, name)); !os.IsNotExist(err) {
+			fact = GoreleaserConfigExistsFact
+			break
+		}
 	}
 	return fact, nil
 }
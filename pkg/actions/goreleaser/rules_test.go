@@ -25,6 +25,22 @@ func TestGoreleaserConfigExistsRule(t *testing.T) {
 		assert.Equal(t, fact, GoreleaserConfigExistsFact)
 	})
 
+	t.Run("GoreleaserConfigExistsFact is true for .goreleaser.yml", func(t *testing.T) {
+		d, err := os.MkdirTemp("", "test-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(d)
+		if err := os.WriteFile(filepath.Join(d, ".goreleaser.yml"), []byte(""), 0744); err != nil {
+			t.Fatal(err)
+		}
+		fact, err := GoreleaserConfigExistsRule(d, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, fact, GoreleaserConfigExistsFact)
+	})
+
 	t.Run("GoreleaserConfigExistsFact is false", func(t *testing.T) {
 		d, err := os.MkdirTemp("", "test-src")
 		if err != nil {
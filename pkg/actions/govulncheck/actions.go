@@ -0,0 +1,75 @@
+package govulncheck
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"dagger.io/dagger"
+	"github.com/trustacks/trustacks/pkg/actions/golang"
+	"github.com/trustacks/trustacks/pkg/engine"
+)
+
+const govulncheckVersion = "v1.1.3"
+
+// reportName returns the report file name of the module.
+func reportName(module string) string {
+	if module == "." {
+		return "root"
+	}
+	return strings.ReplaceAll(filepath.ToSlash(module), "/", "_")
+}
+
+var govulncheckAction = &engine.Action{
+	Name:        "govulncheck",
+	DisplayName: "Govulncheck",
+	Description: "Scan the go dependencies for known vulnerabilities with govulncheck.",
+	Image:       func(_ *engine.Config) string { return "golang" },
+	Stage:       engine.NonFunctionalStage,
+	Caches:      []string{"/go/pkg/mod"},
+	OutputArtifacts: []engine.Artifact{
+		engine.VulnerabilityReportArtifact,
+	},
+	Script: func(container *dagger.Container, _ map[string]interface{}, utils *engine.ActionUtilities) error {
+		container = container.WithExec([]string{"go", "install", fmt.Sprintf("golang.org/x/vuln/cmd/govulncheck@%s", govulncheckVersion)})
+		modules, err := golang.ContainerModules(container)
+		if err != nil {
+			return err
+		}
+		findings := []finding{}
+		for _, module := range modules {
+			moduleContainer := container.Pipeline(module).
+				WithWorkdir(filepath.Join("/src", module)).
+				WithExec([]string{"/bin/sh", "-c", "govulncheck -format json ./... > /tmp/govulncheck.json"}).
+				// the sarif report is converted from the json report
+				// instead of scanning the module again.
+				WithExec([]string{"/bin/sh", "-c", "govulncheck -mode convert -format sarif < /tmp/govulncheck.json > /tmp/govulncheck.sarif"})
+			report, err := moduleContainer.File("/tmp/govulncheck.json").Contents(context.Background())
+			if err != nil {
+				return err
+			}
+			moduleFindings, err := parseFindings(strings.NewReader(report))
+			if err != nil {
+				return err
+			}
+			findings = append(findings, moduleFindings...)
+			name := reportName(module)
+			container = container.
+				WithFile(fmt.Sprintf("/tmp/govulncheck/%s.json", name), moduleContainer.File("/tmp/govulncheck.json")).
+				WithFile(fmt.Sprintf("/tmp/govulncheck/%s.sarif", name), moduleContainer.File("/tmp/govulncheck.sarif"))
+		}
+		if err := utils.Export(container, engine.VulnerabilityReportArtifact, "/tmp/govulncheck"); err != nil {
+			return err
+		}
+		if _, err := container.Sync(context.Background()); err != nil {
+			return err
+		}
+		return checkFindings(findings, utils.GetConfig().Govulncheck.FailOn)
+	},
+	AdmissionCriteria: []engine.Fact{golang.GoModExistsFact},
+}
+
+func init() {
+	engine.RegisterAction(govulncheckAction)
+}
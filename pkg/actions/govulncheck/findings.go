@@ -0,0 +1,101 @@
+package govulncheck
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// The go vulnerability database does not score vulnerabilities, so the
+// gate levels are the reachability of the vulnerable code. Symbol
+// findings call vulnerable functions, package findings import vulnerable
+// packages and module findings only require vulnerable modules.
+const (
+	SymbolLevel  = "symbol"
+	PackageLevel = "package"
+	ModuleLevel  = "module"
+	NoneLevel    = "none"
+)
+
+var levelRanks = map[string]int{
+	NoneLevel:    0,
+	SymbolLevel:  1,
+	PackageLevel: 2,
+	ModuleLevel:  3,
+}
+
+// finding is a vulnerability finding from the govulncheck json output.
+type finding struct {
+	OSV   string `json:"osv"`
+	Trace []struct {
+		Module   string `json:"module"`
+		Package  string `json:"package"`
+		Function string `json:"function"`
+	} `json:"trace"`
+}
+
+// level returns the reachability level of the finding.
+func (f finding) level() string {
+	if len(f.Trace) == 0 {
+		return ModuleLevel
+	}
+	switch {
+	case f.Trace[0].Function != "":
+		return SymbolLevel
+	case f.Trace[0].Package != "":
+		return PackageLevel
+	}
+	return ModuleLevel
+}
+
+// parseFindings decodes the findings from the govulncheck json message
+// stream.
+func parseFindings(r io.Reader) ([]finding, error) {
+	findings := []finding{}
+	decoder := json.NewDecoder(r)
+	for {
+		message := struct {
+			Finding *finding `json:"finding"`
+		}{}
+		if err := decoder.Decode(&message); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		if message.Finding != nil {
+			findings = append(findings, *message.Finding)
+		}
+	}
+	return findings, nil
+}
+
+// checkFindings returns an error if any of the vulnerabilities are at
+// or below the fail on level. The findings are only reported if the
+// level is not configured.
+func checkFindings(findings []finding, failOn string) error {
+	if failOn == "" {
+		failOn = NoneLevel
+	}
+	rank, ok := levelRanks[failOn]
+	if !ok {
+		return fmt.Errorf("unknown govulncheck fail_on level '%s'", failOn)
+	}
+	vulns := map[string]bool{}
+	for _, f := range findings {
+		if levelRanks[f.level()] <= rank {
+			vulns[f.OSV] = true
+		}
+	}
+	if len(vulns) == 0 {
+		return nil
+	}
+	ids := make([]string, 0, len(vulns))
+	for id := range vulns {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return fmt.Errorf("govulncheck found %d vulnerabilities at the '%s' level: %s", len(ids), failOn, strings.Join(ids, ", "))
+}
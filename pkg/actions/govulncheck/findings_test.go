@@ -0,0 +1,40 @@
+package govulncheck
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testReport = `{"config":{"protocol_version":"v1.0.0","scanner_name":"govulncheck"}}
+{"progress":{"message":"Scanning your code and 46 packages across 1 dependent module for known vulnerabilities..."}}
+{"osv":{"id":"GO-2023-0001"}}
+{"finding":{"osv":"GO-2023-0001","trace":[{"module":"example.com/a","version":"v1.0.0"}]}}
+{"finding":{"osv":"GO-2023-0001","trace":[{"module":"example.com/a","version":"v1.0.0","package":"example.com/a/b"}]}}
+{"finding":{"osv":"GO-2023-0002","trace":[{"module":"example.com/c","version":"v1.0.0","package":"example.com/c","function":"Parse"}]}}
+`
+
+func TestParseFindings(t *testing.T) {
+	findings, err := parseFindings(strings.NewReader(testReport))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, findings, 3)
+	assert.Equal(t, ModuleLevel, findings[0].level())
+	assert.Equal(t, PackageLevel, findings[1].level())
+	assert.Equal(t, SymbolLevel, findings[2].level())
+}
+
+func TestCheckFindings(t *testing.T) {
+	findings, err := parseFindings(strings.NewReader(testReport))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, checkFindings(findings, ""))
+	assert.EqualError(t, checkFindings(findings, SymbolLevel), "govulncheck found 1 vulnerabilities at the 'symbol' level: GO-2023-0002")
+	assert.EqualError(t, checkFindings(findings, PackageLevel), "govulncheck found 2 vulnerabilities at the 'package' level: GO-2023-0001, GO-2023-0002")
+	assert.NoError(t, checkFindings(findings, NoneLevel))
+	assert.NoError(t, checkFindings(findings[:1], SymbolLevel))
+	assert.Error(t, checkFindings(findings, "critical"))
+}
@@ -0,0 +1,116 @@
+package java
+
+import (
+	"context"
+	"fmt"
+
+	"dagger.io/dagger"
+	"github.com/trustacks/trustacks/pkg/engine"
+)
+
+// testScript returns the test action script of the build tool. The
+// jacoco report is exported as the coverage artifact if the jacoco
+// plugin is configured.
+func testScript(tool string) func(*dagger.Container, map[string]interface{}, *engine.ActionUtilities) error {
+	return func(container *dagger.Container, _ map[string]interface{}, utils *engine.ActionUtilities) error {
+		entries, err := container.Directory("/src").Entries(context.Background())
+		if err != nil {
+			return err
+		}
+		jacoco, err := containerJacoco(container, tool, entries)
+		if err != nil {
+			return err
+		}
+		container = container.
+			WithEnvVariable("GRADLE_USER_HOME", "/root/.gradle").
+			WithExec(testArgs(tool, utils.HasFact(wrapperFacts[tool]), jacoco))
+		if jacoco {
+			if err := exportCoverage(container, tool, utils); err != nil {
+				return err
+			}
+		}
+		_, err = container.Sync(context.Background())
+		return err
+	}
+}
+
+// packageScript returns the package action script of the build tool.
+// The packaged jars are exported in the .build directory.
+func packageScript(tool string) func(*dagger.Container, map[string]interface{}, *engine.ActionUtilities) error {
+	return func(container *dagger.Container, _ map[string]interface{}, utils *engine.ActionUtilities) error {
+		container = container.
+			WithEnvVariable("GRADLE_USER_HOME", "/root/.gradle").
+			WithExec(packageArgs(tool, utils.HasFact(wrapperFacts[tool]))).
+			WithExec([]string{"/bin/sh", "-c", copyJarsScript(tool, "/tmp/.build")})
+		if err := utils.Export(container, engine.BuildArtifact, "/tmp/.build"); err != nil {
+			return err
+		}
+		_, err := container.Sync(context.Background())
+		return err
+	}
+}
+
+var mavenTest = &engine.Action{
+	Name:        "mavenTest",
+	DisplayName: "Maven Test",
+	Description: "Run the java test suite with maven.",
+	Image:       func(config *engine.Config) string { return fmt.Sprintf("maven:3-eclipse-temurin-%s", version(config)) },
+	Stage:       engine.CommitStage,
+	Caches:      Caches,
+	OutputArtifacts: []engine.Artifact{
+		engine.CoverageArtifact,
+	},
+	Script:            testScript(Maven),
+	AdmissionCriteria: []engine.Fact{MavenProjectExistsFact},
+}
+
+var mavenPackage = &engine.Action{
+	Name:        "mavenPackage",
+	DisplayName: "Maven Package",
+	Description: "Package the java application jars with maven.",
+	Image:       func(config *engine.Config) string { return fmt.Sprintf("maven:3-eclipse-temurin-%s", version(config)) },
+	Stage:       engine.OnDemand,
+	Caches:      Caches,
+	OutputArtifacts: []engine.Artifact{
+		engine.BuildArtifact,
+	},
+	Script:            packageScript(Maven),
+	AdmissionCriteria: []engine.Fact{MavenProjectExistsFact},
+}
+
+var gradleTest = &engine.Action{
+	Name:        "gradleTest",
+	DisplayName: "Gradle Test",
+	Description: "Run the java or kotlin test suite with gradle.",
+	Image:       func(config *engine.Config) string { return fmt.Sprintf("gradle:jdk%s", version(config)) },
+	Stage:       engine.CommitStage,
+	Caches:      Caches,
+	OutputArtifacts: []engine.Artifact{
+		engine.CoverageArtifact,
+	},
+	Script:            testScript(Gradle),
+	AdmissionCriteria: []engine.Fact{GradleProjectExistsFact},
+	ExclusionCriteria: []engine.Fact{MavenProjectExistsFact},
+}
+
+var gradleAssemble = &engine.Action{
+	Name:        "gradleAssemble",
+	DisplayName: "Gradle Assemble",
+	Description: "Assemble the java or kotlin application jars with gradle.",
+	Image:       func(config *engine.Config) string { return fmt.Sprintf("gradle:jdk%s", version(config)) },
+	Stage:       engine.OnDemand,
+	Caches:      Caches,
+	OutputArtifacts: []engine.Artifact{
+		engine.BuildArtifact,
+	},
+	Script:            packageScript(Gradle),
+	AdmissionCriteria: []engine.Fact{GradleProjectExistsFact},
+	ExclusionCriteria: []engine.Fact{MavenProjectExistsFact},
+}
+
+func init() {
+	engine.RegisterAction(mavenTest)
+	engine.RegisterAction(mavenPackage)
+	engine.RegisterAction(gradleTest)
+	engine.RegisterAction(gradleAssemble)
+}
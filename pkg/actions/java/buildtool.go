@@ -0,0 +1,217 @@
+package java
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"dagger.io/dagger"
+	"github.com/trustacks/trustacks/pkg/engine"
+)
+
+const (
+	Maven  = "maven"
+	Gradle = "gradle"
+	// defaultVersion is the default jdk version.
+	defaultVersion = "17"
+	// jacocoCLIURL is the jacoco command line interface used to merge
+	// the module coverage of multi-module builds.
+	jacocoCLIURL = "https://repo1.maven.org/maven2/org/jacoco/org.jacoco.cli/0.8.11/org.jacoco.cli-0.8.11-nodeps.jar"
+)
+
+var (
+	// gradleBuildFiles are the gradle build and settings scripts.
+	gradleBuildFiles = []string{"build.gradle", "build.gradle.kts", "settings.gradle", "settings.gradle.kts"}
+	// wrappers are the wrapper scripts of the build tools.
+	wrappers = map[string]string{
+		Maven:  "mvnw",
+		Gradle: "gradlew",
+	}
+	// wrapperFacts are the wrapper script facts of the build tools.
+	wrapperFacts = map[string]engine.Fact{
+		Maven:  MavenWrapperExistsFact,
+		Gradle: GradleWrapperExistsFact,
+	}
+	// buildFiles are the build files that configure the jacoco plugin.
+	buildFiles = map[string][]string{
+		Maven:  {"pom.xml"},
+		Gradle: {"build.gradle", "build.gradle.kts"},
+	}
+	// coverageReports are the jacoco xml reports of the build tools in
+	// order of precedence. Aggregate reports cover all of the modules.
+	coverageReports = map[string][]string{
+		Maven:  {"target/site/jacoco-aggregate/jacoco.xml", "target/site/jacoco/jacoco.xml"},
+		Gradle: {"build/reports/jacoco/testCodeCoverageReport/testCodeCoverageReport.xml", "build/reports/jacoco/test/jacocoTestReport.xml"},
+	}
+	// jacocoPatterns match the jacoco plugin declarations of the build
+	// files.
+	jacocoPatterns = map[string]*regexp.Regexp{
+		Maven: regexp.MustCompile(`<artifactId>\s*jacoco-maven-plugin\s*</artifactId>`),
+		// ie. id 'jacoco', id("jacoco"), apply plugin: 'jacoco' or the
+		// kotlin dsl plugins { jacoco }.
+		Gradle: regexp.MustCompile(`(?m)\bid\s*\(?\s*["']jacoco["']|\bplugin\s*[:=]\s*["']jacoco["']|^\s*jacoco\s*$`),
+	}
+	// commentPatterns match the comments of the build files.
+	commentPatterns = map[string]*regexp.Regexp{
+		Maven:  regexp.MustCompile(`(?s)<!--.*?-->`),
+		Gradle: regexp.MustCompile(`(?s)/\*.*?\*/|//[^\n]*`),
+	}
+	// execFiles are the find path patterns of the module jacoco
+	// execution data.
+	execFiles = map[string][]string{
+		Maven:  {"*/target/jacoco.exec"},
+		Gradle: {"*/build/jacoco/test.exec"},
+	}
+	// classDirs are the find path patterns of the module class
+	// directories.
+	classDirs = map[string][]string{
+		Maven:  {"*/target/classes"},
+		Gradle: {"*/build/classes/java/main", "*/build/classes/kotlin/main"},
+	}
+	// jarDirs are the find path patterns of the module jar directories.
+	jarDirs = map[string]string{
+		Maven:  "*/target",
+		Gradle: "*/build/libs",
+	}
+)
+
+// Caches are the maven and gradle dependency caches.
+var Caches = []string{"/root/.m2", "/root/.gradle"}
+
+// version returns the configured jdk version.
+func version(config *engine.Config) string {
+	if config.Java.Version != "" {
+		return config.Java.Version
+	}
+	return defaultVersion
+}
+
+// toolArgs returns the build tool command. The wrapper script is used if
+// it exists.
+func toolArgs(tool string, wrapper bool, args ...string) []string {
+	var command []string
+	switch {
+	case wrapper:
+		// wrapper scripts are not always executable in the source.
+		command = []string{"sh", "./" + wrappers[tool]}
+	case tool == Maven:
+		command = []string{"mvn"}
+	default:
+		command = []string{"gradle"}
+	}
+	if tool == Maven {
+		command = append(command, "-B")
+	} else {
+		command = append(command, "--no-daemon")
+	}
+	return append(command, args...)
+}
+
+// jacocoConfigured returns true if the build file declares the jacoco
+// plugin. Comments are ignored.
+func jacocoConfigured(tool, contents string) bool {
+	return jacocoPatterns[tool].MatchString(commentPatterns[tool].ReplaceAllString(contents, ""))
+}
+
+// testArgs returns the test command. The jacoco report is generated if
+// the plugin is configured.
+func testArgs(tool string, wrapper, jacoco bool) []string {
+	if tool == Maven {
+		if jacoco {
+			return toolArgs(tool, wrapper, "test", "jacoco:report")
+		}
+		return toolArgs(tool, wrapper, "test")
+	}
+	if jacoco {
+		return toolArgs(tool, wrapper, "test", "jacocoTestReport")
+	}
+	return toolArgs(tool, wrapper, "test")
+}
+
+// packageArgs returns the command that packages the jars without
+// running the tests.
+func packageArgs(tool string, wrapper bool) []string {
+	if tool == Maven {
+		return toolArgs(tool, wrapper, "package", "-DskipTests")
+	}
+	return toolArgs(tool, wrapper, "assemble")
+}
+
+// copyJarsScript copies the packaged jars of each module to the build
+// output. Source, javadoc and plain (ie. non executable spring boot)
+// jars are not copied.
+func copyJarsScript(tool, output string) string {
+	return fmt.Sprintf(
+		`mkdir -p %[1]s && find . -type f -path '%[2]s/*.jar' ! -path '%[2]s/*/*' ! -name '*-sources.jar' ! -name '*-javadoc.jar' ! -name '*-plain.jar' -exec cp {} %[1]s/ \;`,
+		output,
+		jarDirs[tool],
+	)
+}
+
+// containerJacoco returns true if the container source build files
+// configure the jacoco plugin.
+func containerJacoco(container *dagger.Container, tool string, entries []string) (bool, error) {
+	for _, name := range buildFiles[tool] {
+		if !engine.HasEntry(entries, name) {
+			continue
+		}
+		contents, err := container.File("/src/" + name).Contents(context.Background())
+		if err != nil {
+			return false, err
+		}
+		if jacocoConfigured(tool, contents) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// findArgs returns the find expression that matches any of the path
+// patterns.
+func findArgs(patterns []string) string {
+	args := []string{}
+	for _, pattern := range patterns {
+		args = append(args, fmt.Sprintf("-path '%s'", pattern))
+	}
+	return `\( ` + strings.Join(args, " -o ") + ` \)`
+}
+
+// coverageScript writes the jacoco xml report to the output. The first
+// report of the build tool that exists (ie. an aggregate report) is
+// used. Otherwise the execution data of each module is merged into a
+// single report with the jacoco cli. Nothing is written if the build
+// has no execution data.
+func coverageScript(tool, output string) string {
+	return fmt.Sprintf(`for report in %[2]s; do if [ -f "$report" ]; then cp "$report" %[1]s; exit 0; fi; done
+execs=$(find . -type f %[3]s | tr '\n' ' ')
+[ -n "$execs" ] || exit 0
+mkdir -p /tmp/jacoco
+curl -fsSL -o /tmp/jacoco/cli.jar %[6]s || wget -qO /tmp/jacoco/cli.jar %[6]s
+classes=$(find . -type d %[4]s | sed 's/^/--classfiles /' | tr '\n' ' ')
+sources=$(find . -type d %[5]s | sed 's/^/--sourcefiles /' | tr '\n' ' ')
+java -jar /tmp/jacoco/cli.jar merge $execs --destfile /tmp/jacoco/merged.exec
+java -jar /tmp/jacoco/cli.jar report /tmp/jacoco/merged.exec $classes $sources --xml %[1]s`,
+		output,
+		strings.Join(coverageReports[tool], " "),
+		findArgs(execFiles[tool]),
+		findArgs(classDirs[tool]),
+		findArgs([]string{"*/src/main/java", "*/src/main/kotlin"}),
+		jacocoCLIURL,
+	)
+}
+
+// exportCoverage exports the jacoco report of the build as the coverage
+// artifact. The export is skipped if the build has no coverage.
+func exportCoverage(container *dagger.Container, tool string, utils *engine.ActionUtilities) error {
+	container = container.WithExec([]string{"/bin/sh", "-ec", coverageScript(tool, "jacoco.xml")})
+	entries, err := container.Directory("/src").Entries(context.Background())
+	if err != nil {
+		return err
+	}
+	if !engine.HasEntry(entries, "jacoco.xml") {
+		_, err := container.Sync(context.Background())
+		return err
+	}
+	return utils.Export(container, engine.CoverageArtifact, "jacoco.xml")
+}
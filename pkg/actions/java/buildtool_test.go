@@ -0,0 +1,107 @@
+package java
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustacks/trustacks/pkg/engine"
+)
+
+func TestVersion(t *testing.T) {
+	assert.Equal(t, "17", version(&engine.Config{}))
+	assert.Equal(t, "21", version(&engine.Config{Java: engine.ConfigJava{Version: "21"}}))
+}
+
+func TestToolArgs(t *testing.T) {
+	assert.Equal(t, []string{"mvn", "-B", "test"}, toolArgs(Maven, false, "test"))
+	assert.Equal(t, []string{"sh", "./mvnw", "-B", "test"}, toolArgs(Maven, true, "test"))
+	assert.Equal(t, []string{"gradle", "--no-daemon", "test"}, toolArgs(Gradle, false, "test"))
+	assert.Equal(t, []string{"sh", "./gradlew", "--no-daemon", "test"}, toolArgs(Gradle, true, "test"))
+}
+
+func TestJacocoConfigured(t *testing.T) {
+	tests := []struct {
+		name       string
+		tool       string
+		contents   string
+		configured bool
+	}{
+		{"maven plugin", Maven, "<plugin>\n  <groupId>org.jacoco</groupId>\n  <artifactId>jacoco-maven-plugin</artifactId>\n</plugin>", true},
+		{"maven comment", Maven, "<!-- <artifactId>jacoco-maven-plugin</artifactId> -->", false},
+		{"maven property", Maven, "<jacoco.version>0.8.11</jacoco.version>", false},
+		{"gradle kotlin dsl", Gradle, "plugins {\n    java\n    jacoco\n}\n", true},
+		{"gradle plugin id", Gradle, "plugins {\n    id 'jacoco'\n}\n", true},
+		{"gradle kotlin plugin id", Gradle, "plugins {\n    id(\"jacoco\")\n}\n", true},
+		{"gradle apply plugin", Gradle, "subprojects {\n    apply plugin: 'jacoco'\n}\n", true},
+		{"gradle line comment", Gradle, "plugins {\n    java\n    // jacoco\n}\n", false},
+		{"gradle block comment", Gradle, "/* id 'jacoco' */\nplugins {\n    java\n}\n", false},
+		{"gradle extension", Gradle, "jacoco {\n    toolVersion = \"0.8.11\"\n}\n", false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.configured, jacocoConfigured(test.tool, test.contents))
+		})
+	}
+}
+
+func TestCoverageScript(t *testing.T) {
+	run := func(t *testing.T, tool string, files map[string]string) (string, bool) {
+		d, err := os.MkdirTemp("", "test-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(d)
+		for name, contents := range files {
+			if err := os.MkdirAll(filepath.Join(d, filepath.Dir(name)), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(d, name), []byte(contents), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		cmd := exec.Command("/bin/sh", "-ec", coverageScript(tool, "jacoco.xml"))
+		cmd.Dir = d
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatal(string(out))
+		}
+		report, err := os.ReadFile(filepath.Join(d, "jacoco.xml"))
+		if os.IsNotExist(err) {
+			return "", false
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		return string(report), true
+	}
+	t.Run("aggregate report", func(t *testing.T) {
+		report, ok := run(t, Maven, map[string]string{
+			"target/site/jacoco-aggregate/jacoco.xml": "aggregate",
+			"target/site/jacoco/jacoco.xml":           "root",
+		})
+		assert.True(t, ok)
+		assert.Equal(t, "aggregate", report)
+	})
+	t.Run("gradle test report", func(t *testing.T) {
+		report, ok := run(t, Gradle, map[string]string{"build/reports/jacoco/test/jacocoTestReport.xml": "root"})
+		assert.True(t, ok)
+		assert.Equal(t, "root", report)
+	})
+	t.Run("no coverage", func(t *testing.T) {
+		_, ok := run(t, Maven, map[string]string{"app/target/site/jacoco/index.html": ""})
+		assert.False(t, ok)
+	})
+}
+
+func TestTestArgs(t *testing.T) {
+	assert.Equal(t, []string{"mvn", "-B", "test", "jacoco:report"}, testArgs(Maven, false, true))
+	assert.Equal(t, []string{"mvn", "-B", "test"}, testArgs(Maven, false, false))
+	assert.Equal(t, []string{"gradle", "--no-daemon", "test", "jacocoTestReport"}, testArgs(Gradle, false, true))
+	assert.Equal(t, []string{"gradle", "--no-daemon", "test"}, testArgs(Gradle, false, false))
+}
+
+func TestPackageArgs(t *testing.T) {
+	assert.Equal(t, []string{"mvn", "-B", "package", "-DskipTests"}, packageArgs(Maven, false))
+	assert.Equal(t, []string{"gradle", "--no-daemon", "assemble"}, packageArgs(Gradle, false))
+}
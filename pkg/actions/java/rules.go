@@ -0,0 +1,81 @@
+package java
+
+import (
+	mapset "github.com/deckarep/golang-set/v2"
+	"github.com/trustacks/trustacks/pkg/engine"
+)
+
+var (
+	// MavenProjectExistsFact is true if the pom.xml exists in the root
+	// of the application source.
+	MavenProjectExistsFact = engine.NewFact()
+	// GradleProjectExistsFact is true if a gradle build or settings
+	// script exists in the root of the application source.
+	GradleProjectExistsFact = engine.NewFact()
+	// MavenWrapperExistsFact is true if the mvnw wrapper script exists.
+	MavenWrapperExistsFact = engine.NewFact()
+	// GradleWrapperExistsFact is true if the gradlew wrapper script
+	// exists.
+	GradleWrapperExistsFact = engine.NewFact()
+)
+
+// MavenProjectExistsRule checks if the pom.xml exists in the root of
+// the source.
+var MavenProjectExistsRule engine.Rule = func(source string, _ engine.Collector, _ mapset.Set[engine.Fact]) (engine.Fact, error) {
+	var fact = engine.NilFact
+	ok, err := engine.AnyFileExists(source, "pom.xml")
+	if err != nil {
+		return fact, err
+	}
+	if ok {
+		fact = MavenProjectExistsFact
+	}
+	return fact, nil
+}
+
+// GradleProjectExistsRule checks if the groovy or kotlin gradle build
+// or settings scripts exist in the root of the source.
+var GradleProjectExistsRule engine.Rule = func(source string, _ engine.Collector, _ mapset.Set[engine.Fact]) (engine.Fact, error) {
+	var fact = engine.NilFact
+	ok, err := engine.AnyFileExists(source, gradleBuildFiles...)
+	if err != nil {
+		return fact, err
+	}
+	if ok {
+		fact = GradleProjectExistsFact
+	}
+	return fact, nil
+}
+
+// MavenWrapperExistsRule checks if the mvnw script exists in the root
+// of the source.
+var MavenWrapperExistsRule engine.Rule = func(source string, _ engine.Collector, _ mapset.Set[engine.Fact]) (engine.Fact, error) {
+	var fact = engine.NilFact
+	ok, err := engine.AnyFileExists(source, wrappers[Maven])
+	if err != nil {
+		return fact, err
+	}
+	if ok {
+		fact = MavenWrapperExistsFact
+	}
+	return fact, nil
+}
+
+// GradleWrapperExistsRule checks if the gradlew script exists in the
+// root of the source.
+var GradleWrapperExistsRule engine.Rule = func(source string, _ engine.Collector, _ mapset.Set[engine.Fact]) (engine.Fact, error) {
+	var fact = engine.NilFact
+	ok, err := engine.AnyFileExists(source, wrappers[Gradle])
+	if err != nil {
+		return fact, err
+	}
+	if ok {
+		fact = GradleWrapperExistsFact
+	}
+	return fact, nil
+}
+
+func init() {
+	engine.AddToRuleset(&MavenProjectExistsRule, &MavenWrapperExistsRule)
+	engine.AddToRuleset(&GradleProjectExistsRule, &GradleWrapperExistsRule)
+}
@@ -0,0 +1,138 @@
+package java
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMavenProjectExistsRule(t *testing.T) {
+	t.Run("MavenProjectExistsFact is true", func(t *testing.T) {
+		d, err := os.MkdirTemp("", "test-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(d)
+		if err := os.WriteFile(filepath.Join(d, "pom.xml"), []byte("<project></project>"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		fact, err := MavenProjectExistsRule(d, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, fact, MavenProjectExistsFact)
+	})
+
+	t.Run("MavenProjectExistsFact is false", func(t *testing.T) {
+		d, err := os.MkdirTemp("", "test-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(d)
+		fact, err := MavenProjectExistsRule(d, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.NotEqual(t, fact, MavenProjectExistsFact)
+	})
+}
+
+func TestGradleProjectExistsRule(t *testing.T) {
+	for _, name := range gradleBuildFiles {
+		t.Run("GradleProjectExistsFact is true with "+name, func(t *testing.T) {
+			d, err := os.MkdirTemp("", "test-src")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(d)
+			if err := os.WriteFile(filepath.Join(d, name), []byte{}, 0644); err != nil {
+				t.Fatal(err)
+			}
+			fact, err := GradleProjectExistsRule(d, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, fact, GradleProjectExistsFact)
+		})
+	}
+
+	t.Run("GradleProjectExistsFact is false", func(t *testing.T) {
+		d, err := os.MkdirTemp("", "test-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(d)
+		if err := os.WriteFile(filepath.Join(d, "pom.xml"), []byte{}, 0644); err != nil {
+			t.Fatal(err)
+		}
+		fact, err := GradleProjectExistsRule(d, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.NotEqual(t, fact, GradleProjectExistsFact)
+	})
+}
+
+func TestMavenWrapperExistsRule(t *testing.T) {
+	t.Run("MavenWrapperExistsFact is true", func(t *testing.T) {
+		d, err := os.MkdirTemp("", "test-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(d)
+		if err := os.WriteFile(filepath.Join(d, "mvnw"), []byte("#!/bin/sh\n"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		fact, err := MavenWrapperExistsRule(d, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, fact, MavenWrapperExistsFact)
+	})
+
+	t.Run("MavenWrapperExistsFact is false", func(t *testing.T) {
+		d, err := os.MkdirTemp("", "test-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(d)
+		fact, err := MavenWrapperExistsRule(d, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.NotEqual(t, fact, MavenWrapperExistsFact)
+	})
+}
+
+func TestGradleWrapperExistsRule(t *testing.T) {
+	t.Run("GradleWrapperExistsFact is true", func(t *testing.T) {
+		d, err := os.MkdirTemp("", "test-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(d)
+		if err := os.WriteFile(filepath.Join(d, "gradlew"), []byte("#!/bin/sh\n"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		fact, err := GradleWrapperExistsRule(d, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, fact, GradleWrapperExistsFact)
+	})
+
+	t.Run("GradleWrapperExistsFact is false", func(t *testing.T) {
+		d, err := os.MkdirTemp("", "test-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(d)
+		fact, err := GradleWrapperExistsRule(d, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.NotEqual(t, fact, GradleWrapperExistsFact)
+	})
+}
@@ -0,0 +1,226 @@
+package javascript
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/trustacks/trustacks/pkg/engine"
+)
+
+const (
+	ViteFramework           = "vite"
+	NextFramework           = "next"
+	AngularFramework        = "angular"
+	CreateReactAppFramework = "react-scripts"
+)
+
+// ErrNoBuild is returned when the source has no build script or
+// framework.
+var ErrNoBuild = errors.New("package.json does not have a build script")
+
+var (
+	viteOutDirPattern  = regexp.MustCompile(`outDir\s*:\s*['"]([^'"]+)['"]`)
+	nextDistDirPattern = regexp.MustCompile(`distDir\s*:\s*['"]([^'"]+)['"]`)
+	nextExportPattern  = regexp.MustCompile(`output\s*:\s*['"]export['"]`)
+	viteConfigFiles    = []string{"vite.config.js", "vite.config.ts", "vite.config.mjs", "vite.config.mts", "vite.config.cjs", "vite.config.cts"}
+	nextConfigFiles    = []string{"next.config.js", "next.config.mjs", "next.config.ts", "next.config.cjs"}
+	frameworkBuildArgs = map[string][]string{
+		ViteFramework:           {"vite", "build"},
+		NextFramework:           {"next", "build"},
+		AngularFramework:        {"ng", "build"},
+		CreateReactAppFramework: {"react-scripts", "build"},
+	}
+)
+
+// Build is the build command and output directory of the source.
+type Build struct {
+	Framework string
+	// Script is true if the package.json has a build script.
+	Script bool
+	// OutputDir is the build output directory relative to the source
+	// root.
+	OutputDir string
+}
+
+// Args returns the build command for the package manager.
+func (b Build) Args(manager string) []string {
+	if b.Script {
+		return RunArgs(manager, "build")
+	}
+	args := frameworkBuildArgs[b.Framework]
+	return ExecArgs(manager, args[0], args[1:]...)
+}
+
+// OutputRoot returns the top level directory of the build output that is
+// exported as the build artifact (ie. dist for dist/app).
+func (b Build) OutputRoot() string {
+	root, _, _ := strings.Cut(filepath.ToSlash(b.OutputDir), "/")
+	return root
+}
+
+// firstEntry returns the first candidate that is in the entries.
+func firstEntry(entries []string, candidates []string) string {
+	for _, candidate := range candidates {
+		if engine.HasEntry(entries, candidate) {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// DetectBuild determines the framework, build command and output
+// directory from the framework configuration files and the package.json.
+// An error is returned if the source has no build script or framework.
+func DetectBuild(entries []string, readFile engine.FileReader) (Build, error) {
+	data, err := readFile("package.json")
+	if err != nil {
+		return Build{}, err
+	}
+	packageJSON := struct {
+		Scripts         map[string]string `json:"scripts"`
+		Dependencies    map[string]string `json:"dependencies"`
+		DevDependencies map[string]string `json:"devDependencies"`
+	}{}
+	if err := json.Unmarshal(data, &packageJSON); err != nil {
+		return Build{}, err
+	}
+	dependency := func(name string) bool {
+		_, ok := packageJSON.Dependencies[name]
+		_, okDev := packageJSON.DevDependencies[name]
+		return ok || okDev
+	}
+	_, script := packageJSON.Scripts["build"]
+	build := Build{Script: script, OutputDir: "build"}
+	switch {
+	case engine.HasEntry(entries, "angular.json"):
+		build.Framework = AngularFramework
+		build.OutputDir, err = angularOutputDir(readFile)
+	case firstEntry(entries, nextConfigFiles) != "" || dependency("next"):
+		build.Framework = NextFramework
+		build.OutputDir, err = nextOutputDir(firstEntry(entries, nextConfigFiles), readFile)
+	case firstEntry(entries, viteConfigFiles) != "" || dependency("vite"):
+		build.Framework = ViteFramework
+		build.OutputDir, err = viteOutputDir(firstEntry(entries, viteConfigFiles), readFile)
+	case dependency("react-scripts"):
+		build.Framework = CreateReactAppFramework
+	}
+	if err != nil {
+		return Build{}, err
+	}
+	if !build.Script && build.Framework == "" {
+		return Build{}, ErrNoBuild
+	}
+	return build, nil
+}
+
+// SourceBuild detects the build of the source directory.
+func SourceBuild(source string) (Build, error) {
+	dirEntries, err := os.ReadDir(source)
+	if err != nil {
+		return Build{}, err
+	}
+	entries := []string{}
+	for _, entry := range dirEntries {
+		entries = append(entries, entry.Name())
+	}
+	return DetectBuild(entries, func(name string) ([]byte, error) {
+		return os.ReadFile(filepath.Join(source, name))
+	})
+}
+
+// viteOutputDir returns the build.outDir of the vite configuration.
+func viteOutputDir(config string, readFile engine.FileReader) (string, error) {
+	if config == "" {
+		return "dist", nil
+	}
+	contents, err := readFile(config)
+	if err != nil {
+		return "", err
+	}
+	if match := viteOutDirPattern.FindSubmatch(contents); match != nil {
+		return filepath.Clean(string(match[1])), nil
+	}
+	return "dist", nil
+}
+
+// nextOutputDir returns out for static exports, or the next.js dist
+// directory.
+func nextOutputDir(config string, readFile engine.FileReader) (string, error) {
+	if config == "" {
+		return ".next", nil
+	}
+	contents, err := readFile(config)
+	if err != nil {
+		return "", err
+	}
+	if nextExportPattern.Match(contents) {
+		return "out", nil
+	}
+	if match := nextDistDirPattern.FindSubmatch(contents); match != nil {
+		return filepath.Clean(string(match[1])), nil
+	}
+	return ".next", nil
+}
+
+// angularOutputDir returns the build output path of the default
+// project, or of the first project if there is no default project.
+func angularOutputDir(readFile engine.FileReader) (string, error) {
+	data, err := readFile("angular.json")
+	if err != nil {
+		return "", err
+	}
+	workspace := struct {
+		DefaultProject string `json:"defaultProject"`
+		Projects       map[string]struct {
+			Architect struct {
+				Build struct {
+					Options struct {
+						OutputPath json.RawMessage `json:"outputPath"`
+					} `json:"options"`
+				} `json:"build"`
+			} `json:"architect"`
+		} `json:"projects"`
+	}{}
+	if err := json.Unmarshal(data, &workspace); err != nil {
+		return "", err
+	}
+	name := workspace.DefaultProject
+	if name == "" {
+		names := []string{}
+		for project := range workspace.Projects {
+			names = append(names, project)
+		}
+		if len(names) == 0 {
+			return "", fmt.Errorf("angular.json does not have any projects")
+		}
+		sort.Strings(names)
+		name = names[0]
+	}
+	project, ok := workspace.Projects[name]
+	if !ok {
+		return "", fmt.Errorf("angular project '%s' does not exist", name)
+	}
+	outputPath := project.Architect.Build.Options.OutputPath
+	if len(outputPath) == 0 {
+		return filepath.Join("dist", name), nil
+	}
+	// the output path is a string, or an object with the base path in
+	// angular 17.
+	var path string
+	if err := json.Unmarshal(outputPath, &path); err != nil {
+		options := struct {
+			Base string `json:"base"`
+		}{}
+		if err := json.Unmarshal(outputPath, &options); err != nil {
+			return "", err
+		}
+		path = options.Base
+	}
+	return filepath.Clean(strings.TrimPrefix(path, "./")), nil
+}
@@ -0,0 +1,102 @@
+package javascript
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustacks/trustacks/pkg/engine"
+)
+
+func testFileReader(files map[string]string) ([]string, engine.FileReader) {
+	entries := []string{}
+	for name := range files {
+		entries = append(entries, name)
+	}
+	return entries, func(name string) ([]byte, error) {
+		contents, ok := files[name]
+		if !ok {
+			return nil, os.ErrNotExist
+		}
+		return []byte(contents), nil
+	}
+}
+
+func TestDetectBuild(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		build Build
+	}{
+		{
+			"create react app",
+			map[string]string{"package.json": `{"scripts": {"build": "react-scripts build"}, "dependencies": {"react-scripts": "5.0.1"}}`},
+			Build{Framework: CreateReactAppFramework, Script: true, OutputDir: "build"},
+		},
+		{
+			"vite",
+			map[string]string{"package.json": `{"devDependencies": {"vite": "5.0.0"}}`},
+			Build{Framework: ViteFramework, OutputDir: "dist"},
+		},
+		{
+			"vite outDir",
+			map[string]string{
+				"package.json":   `{"scripts": {"build": "vite build"}}`,
+				"vite.config.ts": `export default defineConfig({ build: { outDir: './public/app' } })`,
+			},
+			Build{Framework: ViteFramework, Script: true, OutputDir: "public/app"},
+		},
+		{
+			"next",
+			map[string]string{"package.json": `{"scripts": {"build": "next build"}, "dependencies": {"next": "14.0.0"}}`},
+			Build{Framework: NextFramework, Script: true, OutputDir: ".next"},
+		},
+		{
+			"next static export",
+			map[string]string{
+				"package.json":   `{"scripts": {"build": "next build"}}`,
+				"next.config.js": `module.exports = { output: 'export' }`,
+			},
+			Build{Framework: NextFramework, Script: true, OutputDir: "out"},
+		},
+		{
+			"angular",
+			map[string]string{
+				"package.json": `{"scripts": {"build": "ng build"}}`,
+				"angular.json": `{"projects": {"web": {"architect": {"build": {"options": {"outputPath": "dist/web"}}}}}}`,
+			},
+			Build{Framework: AngularFramework, Script: true, OutputDir: "dist/web"},
+		},
+		{
+			"angular 17",
+			map[string]string{
+				"package.json": `{"scripts": {"build": "ng build"}}`,
+				"angular.json": `{"projects": {"web": {"architect": {"build": {"options": {"outputPath": {"base": "dist/web"}}}}}}}`,
+			},
+			Build{Framework: AngularFramework, Script: true, OutputDir: "dist/web"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			entries, readFile := testFileReader(test.files)
+			build, err := DetectBuild(entries, readFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, test.build, build)
+		})
+	}
+	entries, readFile := testFileReader(map[string]string{"package.json": `{}`})
+	_, err := DetectBuild(entries, readFile)
+	assert.Equal(t, ErrNoBuild, err)
+}
+
+func TestBuildArgs(t *testing.T) {
+	assert.Equal(t, []string{"pnpm", "run", "build"}, Build{Framework: ViteFramework, Script: true}.Args(Pnpm))
+	assert.Equal(t, []string{"npx", "-y", "vite", "build"}, Build{Framework: ViteFramework}.Args(Npm))
+}
+
+func TestBuildOutputRoot(t *testing.T) {
+	assert.Equal(t, "dist", Build{OutputDir: "dist/web"}.OutputRoot())
+	assert.Equal(t, ".next", Build{OutputDir: ".next"}.OutputRoot())
+}
@@ -0,0 +1,101 @@
+package javascript
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"dagger.io/dagger"
+	"github.com/trustacks/trustacks/pkg/engine"
+)
+
+const (
+	// appServiceHost is the hostname of the application service.
+	appServiceHost = "app"
+	// staticServerPort is the port of the static build output server.
+	staticServerPort = 3000
+	// e2eExitCodePath stores the exit code of the end-to-end suite.
+	e2eExitCodePath = "/tmp/e2e-exit-code"
+)
+
+// ErrNoApplication is returned when there is no built application to
+// run end-to-end tests against.
+var ErrNoApplication = errors.New("end-to-end tests require a container image or a static build output")
+
+// WithAppService starts the built application as a service and binds it
+// to the container. The container image is preferred over the build
+// output, which is served as a static site. The application url is
+// returned.
+func WithAppService(container *dagger.Container, utils *engine.ActionUtilities) (*dagger.Container, string, error) {
+	if images := utils.Images(engine.ContainerImageArtifact); len(images) > 0 {
+		image := images[0].Variants[0]
+		ports, err := image.ExposedPorts(context.Background())
+		if err != nil {
+			return container, "", err
+		}
+		if len(ports) == 0 {
+			return container, "", fmt.Errorf("the container image does not expose a port")
+		}
+		port, err := ports[0].Port(context.Background())
+		if err != nil {
+			return container, "", err
+		}
+		container = container.WithServiceBinding(appServiceHost, image.AsService())
+		return container, fmt.Sprintf("http://%s:%d", appServiceHost, port), nil
+	}
+	server, buildMount, err := utils.Mount(container, engine.BuildArtifact)
+	if err == engine.ErrArtifactNotFound {
+		return container, "", ErrNoApplication
+	} else if err != nil {
+		return container, "", err
+	}
+	entries, err := container.Directory("/src").Entries(context.Background())
+	if err != nil {
+		return container, "", err
+	}
+	build, err := DetectBuild(entries, engine.ContainerFileReader(container))
+	if err != nil {
+		return container, "", err
+	}
+	if build.Framework == NextFramework && build.OutputDir != "out" {
+		return container, "", fmt.Errorf("the next.js build output is not a static export: %w", ErrNoApplication)
+	}
+	service := server.
+		WithExposedPort(staticServerPort).
+		WithExec([]string{"npx", "-y", "serve", "-s", "-l", strconv.Itoa(staticServerPort), buildMount.Path(build.OutputDir)}).
+		AsService()
+	container = container.WithServiceBinding(appServiceHost, service)
+	return container, fmt.Sprintf("http://%s:%d", appServiceHost, staticServerPort), nil
+}
+
+// RunE2E runs the end-to-end suite. The report directories (ie. traces
+// and screenshots) are written to the host reports directory if the
+// suite fails.
+func RunE2E(container *dagger.Container, args []string, reportDirs []string, name string, utils *engine.ActionUtilities) error {
+	container = container.WithExec([]string{
+		"/bin/sh",
+		"-c",
+		fmt.Sprintf("%s; echo $? > %s", strings.Join(args, " "), e2eExitCodePath),
+	})
+	code, err := container.File(e2eExitCodePath).Contents(context.Background())
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(code) == "0" {
+		return nil
+	}
+	for _, dir := range reportDirs {
+		// reports are only written for some failures.
+		entries, err := container.Directory(filepath.Join("/src", filepath.Dir(dir))).Entries(context.Background())
+		if err != nil || !engine.HasEntry(entries, filepath.Base(dir)) {
+			continue
+		}
+		if err := utils.ExportReport(filepath.Join(name, dir), container.Directory(filepath.Join("/src", dir))); err != nil {
+			return err
+		}
+	}
+	return fmt.Errorf("%s end-to-end tests failed with exit code %s", name, strings.TrimSpace(code))
+}
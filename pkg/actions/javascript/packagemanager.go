@@ -0,0 +1,163 @@
+package javascript
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"dagger.io/dagger"
+	"github.com/trustacks/trustacks/pkg/engine"
+)
+
+const (
+	Npm  = "npm"
+	Yarn = "yarn"
+	Pnpm = "pnpm"
+	Bun  = "bun"
+)
+
+// Caches are the dependency cache paths of the supported package
+// managers.
+var Caches = []string{
+	"/src/node_modules",
+	"/root/.npm",
+	"/usr/local/share/.cache/yarn",
+	"/root/.yarn/berry/cache",
+	"/root/.local/share/pnpm/store",
+	"/root/.bun/install/cache",
+}
+
+// lockfiles maps the package manager lockfiles to their package manager
+// in order of precedence.
+var lockfiles = []struct {
+	name    string
+	manager string
+}{
+	{"bun.lockb", Bun},
+	{"bun.lock", Bun},
+	{"pnpm-lock.yaml", Pnpm},
+	{"yarn.lock", Yarn},
+	{"package-lock.json", Npm},
+	{"npm-shrinkwrap.json", Npm},
+}
+
+// packageManagerField returns the package manager name of the
+// packageManager field of the package.json (ie. pnpm@8.15.1 => pnpm).
+func packageManagerField(packageJSON []byte) (string, error) {
+	spec := struct {
+		PackageManager string `json:"packageManager"`
+	}{}
+	if err := json.Unmarshal(packageJSON, &spec); err != nil {
+		return "", err
+	}
+	name, _, _ := strings.Cut(spec.PackageManager, "@")
+	return name, nil
+}
+
+// detectPackageManager returns the package manager from the
+// packageManager field of the package.json, or from the lockfiles in the
+// source root. npm is used if neither exist. Unsupported packageManager
+// fields are ignored.
+func detectPackageManager(packageJSON []byte, entries []string) (string, error) {
+	name, err := packageManagerField(packageJSON)
+	if err != nil {
+		return "", err
+	}
+	switch name {
+	case Npm, Yarn, Pnpm, Bun:
+		return name, nil
+	}
+	for _, lockfile := range lockfiles {
+		if engine.HasEntry(entries, lockfile.name) {
+			return lockfile.manager, nil
+		}
+	}
+	return Npm, nil
+}
+
+// installArgs returns the frozen lockfile install command of the package
+// manager. Sources without a lockfile are installed without a frozen
+// lockfile.
+func installArgs(manager string, entries []string) []string {
+	switch manager {
+	case Yarn:
+		if !engine.HasEntry(entries, "yarn.lock") {
+			return []string{"yarn", "install"}
+		}
+		// yarn berry projects are configured with .yarnrc.yml.
+		if engine.HasEntry(entries, ".yarnrc.yml") {
+			return []string{"yarn", "install", "--immutable"}
+		}
+		return []string{"yarn", "install", "--frozen-lockfile"}
+	case Pnpm:
+		if !engine.HasEntry(entries, "pnpm-lock.yaml") {
+			return []string{"pnpm", "install"}
+		}
+		return []string{"pnpm", "install", "--frozen-lockfile"}
+	case Bun:
+		if !engine.HasEntry(entries, "bun.lockb") && !engine.HasEntry(entries, "bun.lock") {
+			return []string{"bun", "install"}
+		}
+		return []string{"bun", "install", "--frozen-lockfile"}
+	}
+	if engine.HasEntry(entries, "package-lock.json") || engine.HasEntry(entries, "npm-shrinkwrap.json") {
+		return []string{"npm", "ci"}
+	}
+	return []string{"npm", "install"}
+}
+
+// RunArgs returns the command that runs the package.json script with
+// the package manager.
+func RunArgs(manager, script string, args ...string) []string {
+	if manager == Npm {
+		if len(args) > 0 {
+			args = append([]string{"--"}, args...)
+		}
+		return append([]string{"npm", "run", script}, args...)
+	}
+	return append([]string{manager, "run", script}, args...)
+}
+
+// ExecArgs returns the command that runs a package binary with the
+// package manager.
+func ExecArgs(manager, bin string, args ...string) []string {
+	switch manager {
+	case Yarn:
+		return append([]string{"yarn", "run", bin}, args...)
+	case Pnpm:
+		return append([]string{"pnpm", "exec", bin}, args...)
+	case Bun:
+		return append([]string{"bunx", bin}, args...)
+	}
+	return append([]string{"npx", "-y", bin}, args...)
+}
+
+// InstallDependencies installs the source dependencies with the detected
+// package manager and returns the package manager.
+func InstallDependencies(container *dagger.Container) (*dagger.Container, string, error) {
+	entries, err := container.Directory("/src").Entries(context.Background())
+	if err != nil {
+		return container, "", err
+	}
+	packageJSON, err := container.File("/src/package.json").Contents(context.Background())
+	if err != nil {
+		return container, "", err
+	}
+	manager, err := detectPackageManager([]byte(packageJSON), entries)
+	if err != nil {
+		return container, "", err
+	}
+	switch manager {
+	case Yarn, Pnpm:
+		// corepack provides the package manager version from the
+		// packageManager field. corepack is not bundled with node 25
+		// and later.
+		container = container.
+			WithExec([]string{"npm", "install", "-g", "corepack"}).
+			WithExec([]string{"corepack", "enable"})
+	case Bun:
+		container = container.WithExec([]string{"npm", "install", "-g", "bun"})
+	}
+	container = container.WithExec(installArgs(manager, entries))
+	return container, manager, nil
+}
@@ -0,0 +1,59 @@
+package javascript
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectPackageManager(t *testing.T) {
+	tests := []struct {
+		name        string
+		packageJSON string
+		entries     []string
+		manager     string
+	}{
+		{"default", `{}`, []string{"package.json"}, Npm},
+		{"npm lockfile", `{}`, []string{"package-lock.json"}, Npm},
+		{"yarn lockfile", `{}`, []string{"yarn.lock"}, Yarn},
+		{"pnpm lockfile", `{}`, []string{"pnpm-lock.yaml"}, Pnpm},
+		{"bun lockfile", `{}`, []string{"bun.lockb"}, Bun},
+		{"lockfile precedence", `{}`, []string{"package-lock.json", "yarn.lock", "pnpm-lock.yaml"}, Pnpm},
+		{"package manager field", `{"packageManager": "yarn@4.0.2"}`, []string{"package-lock.json"}, Yarn},
+		{"unsupported package manager field", `{"packageManager": "rush@5.0.0"}`, []string{"yarn.lock"}, Yarn},
+		{"unsupported package manager default", `{"packageManager": "rush@5.0.0"}`, nil, Npm},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			manager, err := detectPackageManager([]byte(test.packageJSON), test.entries)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, test.manager, manager)
+		})
+	}
+}
+
+func TestInstallArgs(t *testing.T) {
+	assert.Equal(t, []string{"npm", "install"}, installArgs(Npm, nil))
+	assert.Equal(t, []string{"npm", "ci"}, installArgs(Npm, []string{"package-lock.json"}))
+	assert.Equal(t, []string{"yarn", "install", "--frozen-lockfile"}, installArgs(Yarn, []string{"yarn.lock"}))
+	assert.Equal(t, []string{"yarn", "install", "--immutable"}, installArgs(Yarn, []string{"yarn.lock", ".yarnrc.yml"}))
+	assert.Equal(t, []string{"pnpm", "install", "--frozen-lockfile"}, installArgs(Pnpm, []string{"pnpm-lock.yaml"}))
+	assert.Equal(t, []string{"bun", "install", "--frozen-lockfile"}, installArgs(Bun, []string{"bun.lockb"}))
+	assert.Equal(t, []string{"bun", "install"}, installArgs(Bun, nil))
+}
+
+func TestRunArgs(t *testing.T) {
+	assert.Equal(t, []string{"npm", "run", "build"}, RunArgs(Npm, "build"))
+	assert.Equal(t, []string{"npm", "run", "test", "--", "--coverage"}, RunArgs(Npm, "test", "--coverage"))
+	assert.Equal(t, []string{"yarn", "run", "test", "--coverage"}, RunArgs(Yarn, "test", "--coverage"))
+	assert.Equal(t, []string{"bun", "run", "test"}, RunArgs(Bun, "test"))
+}
+
+func TestExecArgs(t *testing.T) {
+	assert.Equal(t, []string{"npx", "-y", "eslint", "./"}, ExecArgs(Npm, "eslint", "./"))
+	assert.Equal(t, []string{"yarn", "run", "eslint", "./"}, ExecArgs(Yarn, "eslint", "./"))
+	assert.Equal(t, []string{"pnpm", "exec", "eslint", "./"}, ExecArgs(Pnpm, "eslint", "./"))
+	assert.Equal(t, []string{"bunx", "eslint", "./"}, ExecArgs(Bun, "eslint", "./"))
+}
@@ -16,8 +16,23 @@ var (
 	// PackageJSONVersionExistsFact is true if the package.json file
 	// contains the version key.
 	PackageJSONVersionExistsFact = engine.NewFact()
+	// NpmPackageManagerFact is true if the source is managed with npm.
+	NpmPackageManagerFact = engine.NewFact()
+	// YarnPackageManagerFact is true if the source is managed with yarn.
+	YarnPackageManagerFact = engine.NewFact()
+	// PnpmPackageManagerFact is true if the source is managed with pnpm.
+	PnpmPackageManagerFact = engine.NewFact()
+	// BunPackageManagerFact is true if the source is managed with bun.
+	BunPackageManagerFact = engine.NewFact()
 )
 
+var packageManagerFacts = map[string]engine.Fact{
+	Npm:  NpmPackageManagerFact,
+	Yarn: YarnPackageManagerFact,
+	Pnpm: PnpmPackageManagerFact,
+	Bun:  BunPackageManagerFact,
+}
+
 // PackageJSONExistsRule checks if the package.json file exits in the
 // root of the filesystem.
 var PackageJSONExistsRule engine.Rule = func(source string, _ engine.Collector, _ mapset.Set[engine.Fact]) (engine.Fact, error) {
@@ -49,6 +64,31 @@ var PackageJSONVersionExistsRule engine.Rule = func(source string, _ engine.Coll
 	return fact, nil
 }
 
+// PackageManagerRule detects the package manager from the
+// packageManager field of the package.json and the source lockfiles.
+var PackageManagerRule engine.Rule = func(source string, _ engine.Collector, _ mapset.Set[engine.Fact]) (engine.Fact, error) {
+	var fact = engine.NilFact
+	data, err := os.ReadFile(filepath.Join(source, "package.json"))
+	if err != nil {
+		return fact, err
+	}
+	dirEntries, err := os.ReadDir(source)
+	if err != nil {
+		return fact, err
+	}
+	entries := []string{}
+	for _, entry := range dirEntries {
+		entries = append(entries, entry.Name())
+	}
+	manager, err := detectPackageManager(data, entries)
+	if err != nil {
+		return fact, err
+	}
+	fact = packageManagerFacts[manager]
+	return fact, nil
+}
+
 func init() {
 	engine.AddToRuleset(&PackageJSONExistsRule, &PackageJSONVersionExistsRule)
+	engine.AddToRuleset(&PackageJSONExistsRule, &PackageManagerRule)
 }
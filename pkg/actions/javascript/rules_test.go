@@ -72,3 +72,40 @@ func TestPackageJSONVersionExistsRule(t *testing.T) {
 		assert.NotEqual(t, fact, PackageJSONVersionExistsFact)
 	})
 }
+
+func TestPackageManagerRule(t *testing.T) {
+	t.Run("PnpmPackageManagerFact is true", func(t *testing.T) {
+		d, err := os.MkdirTemp("", "test-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(d)
+		if err := os.WriteFile(filepath.Join(d, "package.json"), []byte(`{}`), 0744); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(d, "pnpm-lock.yaml"), []byte(``), 0744); err != nil {
+			t.Fatal(err)
+		}
+		fact, err := PackageManagerRule(d, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, fact, PnpmPackageManagerFact)
+	})
+
+	t.Run("NpmPackageManagerFact is true", func(t *testing.T) {
+		d, err := os.MkdirTemp("", "test-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(d)
+		if err := os.WriteFile(filepath.Join(d, "package.json"), []byte(`{}`), 0744); err != nil {
+			t.Fatal(err)
+		}
+		fact, err := PackageManagerRule(d, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, fact, NpmPackageManagerFact)
+	})
+}
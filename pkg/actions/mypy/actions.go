@@ -0,0 +1,51 @@
+package mypy
+
+import (
+	"context"
+	"strings"
+
+	"dagger.io/dagger"
+	"github.com/trustacks/trustacks/pkg/actions/python"
+	"github.com/trustacks/trustacks/pkg/engine"
+)
+
+var mypyRunAction = &engine.Action{
+	Name:        "mypyRun",
+	DisplayName: "Mypy Run",
+	Description: "Type check the python source with mypy.",
+	Image:       python.Image,
+	Stage:       engine.CommitStage,
+	Caches:      append([]string{"/src/.mypy_cache"}, python.Caches...),
+	Script: func(container *dagger.Container, _ map[string]interface{}, utils *engine.ActionUtilities) error {
+		config := utils.GetConfig()
+		container = container.WithExec([]string{"apt", "update"})
+		container = container.WithExec([]string{"apt", "install", "gcc", "-y"})
+		container = container.WithExec([]string{"apt", "install", "-y", strings.Join(config.Python.Libraries, " ")})
+		// mypy checks the imports against the installed dependencies.
+		container, _, err := python.InstallPythonDependencies(container)
+		if err != nil {
+			return err
+		}
+		if config.Python.DevRequirements != "" {
+			container = container.WithExec([]string{"pip", "install", "-r", config.Python.DevRequirements})
+		}
+		entries, err := container.Directory("/src").Entries(context.Background())
+		if err != nil {
+			return err
+		}
+		mypy, _, err := detectMypyConfig(entries, engine.ContainerFileReader(container))
+		if err != nil {
+			return err
+		}
+		container = container.
+			WithExec([]string{"pip", "install", "mypy"}).
+			WithExec(mypy.Args())
+		_, err = container.Sync(context.Background())
+		return err
+	},
+	AdmissionCriteria: []engine.Fact{MypyConfigExistsFact},
+}
+
+func init() {
+	engine.RegisterAction(mypyRunAction)
+}
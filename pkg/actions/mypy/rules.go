@@ -0,0 +1,109 @@
+package mypy
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+
+	"github.com/bigkevmcd/go-configparser"
+	mapset "github.com/deckarep/golang-set/v2"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/trustacks/trustacks/pkg/actions/python"
+	"github.com/trustacks/trustacks/pkg/engine"
+)
+
+var (
+	// MypyConfigExistsFact is true if mypy is configured in the mypy.ini,
+	// .mypy.ini, pyproject.toml or setup.cfg.
+	MypyConfigExistsFact = engine.NewFact()
+)
+
+// mypyConfigFiles are the mypy configuration files in the order that
+// mypy reads them.
+var mypyConfigFiles = []string{"mypy.ini", ".mypy.ini", "pyproject.toml", "setup.cfg"}
+
+// mypyConfig is the mypy configuration of the source.
+type mypyConfig struct {
+	// File is the configuration file.
+	File string
+	// Files is true if the configuration sets the files to check.
+	Files bool
+}
+
+// Args returns the mypy command. The source root is checked if the
+// configuration does not set the files to check.
+func (c mypyConfig) Args() []string {
+	if c.Files {
+		return []string{"mypy"}
+	}
+	return []string{"mypy", "."}
+}
+
+// detectMypyConfig returns the first mypy configuration file that
+// configures mypy.
+func detectMypyConfig(entries []string, readFile engine.FileReader) (mypyConfig, bool, error) {
+	for _, name := range mypyConfigFiles {
+		if !engine.HasEntry(entries, name) {
+			continue
+		}
+		data, err := readFile(name)
+		if err != nil {
+			return mypyConfig{}, false, err
+		}
+		if name == "pyproject.toml" {
+			project := struct {
+				Tool struct {
+					Mypy map[string]interface{} `toml:"mypy"`
+				} `toml:"tool"`
+			}{}
+			if err := toml.Unmarshal(data, &project); err != nil {
+				return mypyConfig{}, false, err
+			}
+			if project.Tool.Mypy == nil {
+				continue
+			}
+			_, files := project.Tool.Mypy["files"]
+			return mypyConfig{File: name, Files: files}, true, nil
+		}
+		cfg, err := configparser.ParseReader(bytes.NewReader(data))
+		if err != nil {
+			return mypyConfig{}, false, err
+		}
+		if !cfg.HasSection("mypy") {
+			continue
+		}
+		files, err := cfg.HasOption("mypy", "files")
+		if err != nil {
+			return mypyConfig{}, false, err
+		}
+		return mypyConfig{File: name, Files: files}, true, nil
+	}
+	return mypyConfig{}, false, nil
+}
+
+// MypyConfigExistsRule checks if mypy is configured in the source root.
+var MypyConfigExistsRule engine.Rule = func(source string, _ engine.Collector, _ mapset.Set[engine.Fact]) (engine.Fact, error) {
+	var fact = engine.NilFact
+	dirEntries, err := os.ReadDir(source)
+	if err != nil {
+		return fact, err
+	}
+	entries := []string{}
+	for _, entry := range dirEntries {
+		entries = append(entries, entry.Name())
+	}
+	_, ok, err := detectMypyConfig(entries, func(name string) ([]byte, error) {
+		return os.ReadFile(filepath.Join(source, name))
+	})
+	if err != nil {
+		return fact, err
+	}
+	if ok {
+		fact = MypyConfigExistsFact
+	}
+	return fact, nil
+}
+
+func init() {
+	engine.AddToRuleset(&python.PackageManagerRule, &MypyConfigExistsRule)
+}
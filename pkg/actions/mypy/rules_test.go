@@ -0,0 +1,80 @@
+package mypy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMypyConfigExistsRule(t *testing.T) {
+	t.Run("MypyConfigExistsFact is true", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			contents string
+		}{
+			{"mypy.ini", "[mypy]\nstrict = True\n"},
+			{".mypy.ini", "[mypy]\n"},
+			{"setup.cfg", "[metadata]\nname = app\n\n[mypy]\nstrict = True\n"},
+			{"pyproject.toml", "[tool.mypy]\nstrict = true\n"},
+		}
+		for _, tc := range tests {
+			d, err := os.MkdirTemp("", "test-src")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(d)
+			if err := os.WriteFile(filepath.Join(d, tc.name), []byte(tc.contents), 0744); err != nil {
+				t.Fatal(err)
+			}
+			fact, err := MypyConfigExistsRule(d, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, fact, MypyConfigExistsFact, tc.name)
+		}
+	})
+
+	t.Run("MypyConfigExistsFact is false", func(t *testing.T) {
+		d, err := os.MkdirTemp("", "test-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(d)
+		if err := os.WriteFile(filepath.Join(d, "setup.cfg"), []byte("[flake8]\n"), 0744); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(d, "pyproject.toml"), []byte("[tool.black]\n"), 0744); err != nil {
+			t.Fatal(err)
+		}
+		fact, err := MypyConfigExistsRule(d, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.NotEqual(t, fact, MypyConfigExistsFact)
+	})
+}
+
+func TestDetectMypyConfig(t *testing.T) {
+	files := map[string]string{
+		"mypy.ini":       "[mypy]\nfiles = src\n",
+		"pyproject.toml": "[tool.mypy]\nstrict = true\n",
+	}
+	readFile := func(name string) ([]byte, error) { return []byte(files[name]), nil }
+
+	config, ok, err := detectMypyConfig([]string{"pyproject.toml", "mypy.ini"}, readFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, ok)
+	assert.Equal(t, mypyConfig{File: "mypy.ini", Files: true}, config)
+	assert.Equal(t, []string{"mypy"}, config.Args())
+
+	config, ok, err = detectMypyConfig([]string{"pyproject.toml"}, readFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, ok)
+	assert.Equal(t, []string{"mypy", "."}, config.Args())
+}
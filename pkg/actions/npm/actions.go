@@ -2,25 +2,59 @@ package npm
 
 import (
 	"context"
+	"encoding/json"
 	"path/filepath"
+	"regexp"
 
 	"dagger.io/dagger"
+	"github.com/trustacks/trustacks/pkg/actions/javascript"
 	"github.com/trustacks/trustacks/pkg/engine"
 )
 
+// coverageRunnerPattern matches the test runners that accept the
+// --coverage flag (jest, vitest and the create-react-app jest wrapper).
+var coverageRunnerPattern = regexp.MustCompile(`(^|[\s;&|/])(jest|vitest|react-scripts test)(\s|$)`)
+
+// testArgs returns the command that runs the package.json test script.
+// Coverage is only collected if the test runner accepts the --coverage
+// flag.
+func testArgs(manager string, packageJSON []byte) ([]string, error) {
+	spec := struct {
+		Scripts map[string]string `json:"scripts"`
+	}{}
+	if err := json.Unmarshal(packageJSON, &spec); err != nil {
+		return nil, err
+	}
+	if coverageRunnerPattern.MatchString(spec.Scripts["test"]) {
+		return javascript.RunArgs(manager, "test", "--coverage"), nil
+	}
+	return javascript.RunArgs(manager, "test"), nil
+}
+
 var npmTestAction = &engine.Action{
 	Name:        "npmTest",
 	DisplayName: "Npm Test",
 	Description: "Run the test suite with npm test.",
 	Image:       func(_ *engine.Config) string { return "node:alpine" },
 	Stage:       engine.CommitStage,
-	Caches:      []string{"/src/node_modules"},
+	Caches:      javascript.Caches,
 	Script: func(container *dagger.Container, _ map[string]interface{}, _ *engine.ActionUtilities) error {
 		container = container.WithExec([]string{"apk", "add", "bash"})
-		container = container.WithExec([]string{"npm", "install"})
+		container, manager, err := javascript.InstallDependencies(container)
+		if err != nil {
+			return err
+		}
+		packageJSON, err := container.File("/src/package.json").Contents(context.Background())
+		if err != nil {
+			return err
+		}
+		args, err := testArgs(manager, []byte(packageJSON))
+		if err != nil {
+			return err
+		}
 		container = container.WithEnvVariable("CI", "true")
-		container = container.WithExec([]string{"npm", "test", "--coverage"})
-		_, err := container.Sync(context.Background())
+		container = container.WithExec(args)
+		_, err = container.Sync(context.Background())
 		return err
 	},
 	AdmissionCriteria: []engine.Fact{NpmTestExistsFact},
@@ -29,18 +63,31 @@ var npmTestAction = &engine.Action{
 var npmBuildAction = &engine.Action{
 	Name:        "npmBuild",
 	DisplayName: "Npm Build",
-	Description: "Build the application with npm run build.",
+	Description: "Build the application with the package.json build script or the framework build command.",
 	Image:       func(_ *engine.Config) string { return "node:alpine" },
 	Stage:       engine.OnDemand,
-	Caches:      []string{"/src/node_modules"},
+	Caches:      javascript.Caches,
 	OutputArtifacts: []engine.Artifact{
 		engine.BuildArtifact,
 	},
 	Script: func(container *dagger.Container, _ map[string]interface{}, utils *engine.ActionUtilities) error {
 		container = container.WithExec([]string{"apk", "add", "bash"})
-		container = container.WithExec([]string{"npm", "install"})
-		container = container.WithExec([]string{"npm", "run", "build"})
-		return utils.Export(container, engine.BuildArtifact, filepath.Join("/src", "build"))
+		container, manager, err := javascript.InstallDependencies(container)
+		if err != nil {
+			return err
+		}
+		entries, err := container.Directory("/src").Entries(context.Background())
+		if err != nil {
+			return err
+		}
+		build, err := javascript.DetectBuild(entries, engine.ContainerFileReader(container))
+		if err != nil {
+			return err
+		}
+		container = container.WithExec(build.Args(manager))
+		// the output root is exported so that the output keeps its path
+		// relative to the source root (ie. dist/app).
+		return utils.Export(container, engine.BuildArtifact, filepath.Join("/src", build.OutputRoot()))
 	},
 	AdmissionCriteria: []engine.Fact{NpmBuildExistsFact},
 }
@@ -0,0 +1,33 @@
+package npm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustacks/trustacks/pkg/actions/javascript"
+)
+
+func TestTestArgs(t *testing.T) {
+	tests := []struct {
+		name   string
+		script string
+		args   []string
+	}{
+		{"jest", "jest", []string{"npm", "run", "test", "--", "--coverage"}},
+		{"jest with options", "NODE_ENV=test jest --runInBand", []string{"npm", "run", "test", "--", "--coverage"}},
+		{"vitest", "vitest run", []string{"npm", "run", "test", "--", "--coverage"}},
+		{"react-scripts", "react-scripts test", []string{"npm", "run", "test", "--", "--coverage"}},
+		{"mocha", "mocha test/*.spec.js", []string{"npm", "run", "test"}},
+		{"node test runner", "node --test", []string{"npm", "run", "test"}},
+		{"jest config name", "mocha --config jest-like.json", []string{"npm", "run", "test"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			args, err := testArgs(javascript.Npm, []byte(`{"scripts": {"test": "`+test.script+`"}}`))
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, test.args, args)
+		})
+	}
+}
@@ -21,8 +21,7 @@ type npmPackageJSONSpec struct {
 }
 
 type npmPackageJSONSpecScripts struct {
-	Build *string `json:"build,omitempty"`
-	Test  *string `json:"test,omitempty"`
+	Test *string `json:"test,omitempty"`
 }
 
 var NpmTestExsitsRule engine.Rule = func(source string, collector engine.Collector, _ mapset.Set[engine.Fact]) (engine.Fact, error) {
@@ -46,19 +45,16 @@ var NpmTestExsitsRule engine.Rule = func(source string, collector engine.Collect
 	return fact, nil
 }
 
+// NpmBuildExsitsRule is true if the package.json has a build script or
+// the source uses a framework with a known build command.
 var NpmBuildExsitsRule engine.Rule = func(source string, _ engine.Collector, _ mapset.Set[engine.Fact]) (engine.Fact, error) {
 	var fact = engine.NilFact
-	packageJSON := &npmPackageJSONSpec{}
-	data, err := os.ReadFile(filepath.Join(source, "package.json"))
-	if err != nil {
+	if _, err := javascript.SourceBuild(source); err == javascript.ErrNoBuild {
+		return fact, nil
+	} else if err != nil {
 		return fact, err
 	}
-	if err := json.Unmarshal(data, &packageJSON); err != nil {
-		return fact, err
-	}
-	if packageJSON.Scripts.Build != nil {
-		fact = NpmBuildExistsFact
-	}
+	fact = NpmBuildExistsFact
 	return fact, nil
 }
 
@@ -66,6 +66,21 @@ func TestNpmBuildExsitsRule(t *testing.T) {
 		assert.Equal(t, fact, NpmBuildExistsFact)
 	})
 
+	t.Run("NpmBuildExistsFact is true for frameworks without a build script", func(t *testing.T) {
+		d, err := os.MkdirTemp("", "test-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(d, "package.json"), []byte(`{"devDependencies":{"vite": "5.0.0"}}`), 0744); err != nil {
+			t.Fatal(err)
+		}
+		fact, err := NpmBuildExsitsRule(d, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, fact, NpmBuildExistsFact)
+	})
+
 	t.Run("NpmBuildExistsFact is false", func(t *testing.T) {
 		d, err := os.MkdirTemp("", "test-src")
 		if err != nil {
@@ -0,0 +1,79 @@
+package php
+
+import (
+	"context"
+
+	"dagger.io/dagger"
+	"github.com/trustacks/trustacks/pkg/engine"
+)
+
+// caches are the composer package caches.
+var caches = []string{"/root/.composer/cache"}
+
+// installArgs returns the composer command that installs the
+// dependencies. The locked dependencies are installed if the
+// composer.lock exists, otherwise the dependencies are resolved.
+func installArgs(locked bool) []string {
+	command := "update"
+	if locked {
+		command = "install"
+	}
+	return []string{"composer", command, "--no-interaction", "--no-progress", "--prefer-dist"}
+}
+
+// composerInstall installs the composer.json dependencies with the
+// composer.json php version.
+func composerInstall(container *dagger.Container, utils *engine.ActionUtilities) (*dagger.Container, error) {
+	container, err := withComposer(container)
+	if err != nil {
+		return nil, err
+	}
+	return container.
+		WithEnvVariable("COMPOSER_CACHE_DIR", "/root/.composer/cache").
+		WithExec(installArgs(utils.HasFact(ComposerLockExistsFact))), nil
+}
+
+var phpunitRun = &engine.Action{
+	Name:        "phpunitRun",
+	DisplayName: "PHPUnit Run",
+	Description: "Run the php test suite with phpunit.",
+	Image:       func(_ *engine.Config) string { return phpImage(defaultVersion) },
+	Stage:       engine.CommitStage,
+	Caches:      caches,
+	Script: func(container *dagger.Container, _ map[string]interface{}, utils *engine.ActionUtilities) error {
+		container, err := composerInstall(container, utils)
+		if err != nil {
+			return err
+		}
+		_, err = container.
+			WithExec([]string{"vendor/bin/phpunit"}).
+			Sync(context.Background())
+		return err
+	},
+	AdmissionCriteria: []engine.Fact{PhpunitConfigExistsFact},
+}
+
+var phpstanRun = &engine.Action{
+	Name:        "phpstanRun",
+	DisplayName: "PHPStan Run",
+	Description: "Analyse the php source with phpstan.",
+	Image:       func(_ *engine.Config) string { return phpImage(defaultVersion) },
+	Stage:       engine.CommitStage,
+	Caches:      caches,
+	Script: func(container *dagger.Container, _ map[string]interface{}, utils *engine.ActionUtilities) error {
+		container, err := composerInstall(container, utils)
+		if err != nil {
+			return err
+		}
+		_, err = container.
+			WithExec([]string{"vendor/bin/phpstan", "analyse", "--no-progress", "--memory-limit=-1"}).
+			Sync(context.Background())
+		return err
+	},
+	AdmissionCriteria: []engine.Fact{PhpstanConfigExistsFact},
+}
+
+func init() {
+	engine.RegisterAction(phpunitRun)
+	engine.RegisterAction(phpstanRun)
+}
@@ -0,0 +1,12 @@
+package php
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInstallArgs(t *testing.T) {
+	assert.Equal(t, []string{"composer", "install", "--no-interaction", "--no-progress", "--prefer-dist"}, installArgs(true))
+	assert.Equal(t, []string{"composer", "update", "--no-interaction", "--no-progress", "--prefer-dist"}, installArgs(false))
+}
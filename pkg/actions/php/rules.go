@@ -0,0 +1,90 @@
+package php
+
+import (
+	mapset "github.com/deckarep/golang-set/v2"
+	"github.com/trustacks/trustacks/pkg/engine"
+)
+
+var (
+	// ComposerJSONExistsFact is true if the composer.json exists in the
+	// root of the application source.
+	ComposerJSONExistsFact = engine.NewFact()
+	// ComposerLockExistsFact is true if the composer.lock exists in the
+	// root of the application source.
+	ComposerLockExistsFact = engine.NewFact()
+	// PhpunitConfigExistsFact is true if the phpunit configuration
+	// exists.
+	PhpunitConfigExistsFact = engine.NewFact()
+	// PhpstanConfigExistsFact is true if the phpstan configuration
+	// exists.
+	PhpstanConfigExistsFact = engine.NewFact()
+)
+
+var (
+	// phpunitConfigs are the phpunit configuration files.
+	phpunitConfigs = []string{"phpunit.xml", "phpunit.xml.dist", "phpunit.dist.xml"}
+	// phpstanConfigs are the phpstan configuration files.
+	phpstanConfigs = []string{"phpstan.neon", "phpstan.neon.dist", "phpstan.dist.neon"}
+)
+
+// ComposerJSONExistsRule checks if the composer.json exists in the root
+// of the source.
+var ComposerJSONExistsRule engine.Rule = func(source string, _ engine.Collector, _ mapset.Set[engine.Fact]) (engine.Fact, error) {
+	var fact = engine.NilFact
+	ok, err := engine.AnyFileExists(source, "composer.json")
+	if err != nil {
+		return fact, err
+	}
+	if ok {
+		fact = ComposerJSONExistsFact
+	}
+	return fact, nil
+}
+
+// ComposerLockExistsRule checks if the composer.lock exists in the root
+// of the source.
+var ComposerLockExistsRule engine.Rule = func(source string, _ engine.Collector, _ mapset.Set[engine.Fact]) (engine.Fact, error) {
+	var fact = engine.NilFact
+	ok, err := engine.AnyFileExists(source, "composer.lock")
+	if err != nil {
+		return fact, err
+	}
+	if ok {
+		fact = ComposerLockExistsFact
+	}
+	return fact, nil
+}
+
+// PhpunitConfigExistsRule checks if the phpunit configuration exists in
+// the root of the source.
+var PhpunitConfigExistsRule engine.Rule = func(source string, _ engine.Collector, _ mapset.Set[engine.Fact]) (engine.Fact, error) {
+	var fact = engine.NilFact
+	ok, err := engine.AnyFileExists(source, phpunitConfigs...)
+	if err != nil {
+		return fact, err
+	}
+	if ok {
+		fact = PhpunitConfigExistsFact
+	}
+	return fact, nil
+}
+
+// PhpstanConfigExistsRule checks if the phpstan configuration exists in
+// the root of the source.
+var PhpstanConfigExistsRule engine.Rule = func(source string, _ engine.Collector, _ mapset.Set[engine.Fact]) (engine.Fact, error) {
+	var fact = engine.NilFact
+	ok, err := engine.AnyFileExists(source, phpstanConfigs...)
+	if err != nil {
+		return fact, err
+	}
+	if ok {
+		fact = PhpstanConfigExistsFact
+	}
+	return fact, nil
+}
+
+func init() {
+	engine.AddToRuleset(&ComposerJSONExistsRule, &ComposerLockExistsRule)
+	engine.AddToRuleset(&ComposerJSONExistsRule, &PhpunitConfigExistsRule)
+	engine.AddToRuleset(&ComposerJSONExistsRule, &PhpstanConfigExistsRule)
+}
@@ -0,0 +1,137 @@
+package php
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComposerJSONExistsRule(t *testing.T) {
+	t.Run("ComposerJSONExistsFact is true", func(t *testing.T) {
+		d, err := os.MkdirTemp("", "test-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(d)
+		if err := os.WriteFile(filepath.Join(d, "composer.json"), []byte{}, 0644); err != nil {
+			t.Fatal(err)
+		}
+		fact, err := ComposerJSONExistsRule(d, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, fact, ComposerJSONExistsFact)
+	})
+
+	t.Run("ComposerJSONExistsFact is false", func(t *testing.T) {
+		d, err := os.MkdirTemp("", "test-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(d)
+		fact, err := ComposerJSONExistsRule(d, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.NotEqual(t, fact, ComposerJSONExistsFact)
+	})
+}
+
+func TestComposerLockExistsRule(t *testing.T) {
+	t.Run("ComposerLockExistsFact is true", func(t *testing.T) {
+		d, err := os.MkdirTemp("", "test-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(d)
+		if err := os.WriteFile(filepath.Join(d, "composer.lock"), []byte{}, 0644); err != nil {
+			t.Fatal(err)
+		}
+		fact, err := ComposerLockExistsRule(d, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, fact, ComposerLockExistsFact)
+	})
+
+	t.Run("ComposerLockExistsFact is false", func(t *testing.T) {
+		d, err := os.MkdirTemp("", "test-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(d)
+		fact, err := ComposerLockExistsRule(d, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.NotEqual(t, fact, ComposerLockExistsFact)
+	})
+}
+
+func TestPhpunitConfigExistsRule(t *testing.T) {
+	for _, name := range []string{"phpunit.xml", "phpunit.xml.dist", "phpunit.dist.xml"} {
+		t.Run("PhpunitConfigExistsFact is true with "+name, func(t *testing.T) {
+			d, err := os.MkdirTemp("", "test-src")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(d)
+			if err := os.WriteFile(filepath.Join(d, filepath.FromSlash(name)), []byte{}, 0644); err != nil {
+				t.Fatal(err)
+			}
+			fact, err := PhpunitConfigExistsRule(d, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, fact, PhpunitConfigExistsFact)
+		})
+	}
+
+	t.Run("PhpunitConfigExistsFact is false", func(t *testing.T) {
+		d, err := os.MkdirTemp("", "test-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(d)
+		fact, err := PhpunitConfigExistsRule(d, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.NotEqual(t, fact, PhpunitConfigExistsFact)
+	})
+}
+
+func TestPhpstanConfigExistsRule(t *testing.T) {
+	for _, name := range []string{"phpstan.neon", "phpstan.neon.dist", "phpstan.dist.neon"} {
+		t.Run("PhpstanConfigExistsFact is true with "+name, func(t *testing.T) {
+			d, err := os.MkdirTemp("", "test-src")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(d)
+			if err := os.WriteFile(filepath.Join(d, filepath.FromSlash(name)), []byte{}, 0644); err != nil {
+				t.Fatal(err)
+			}
+			fact, err := PhpstanConfigExistsRule(d, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, fact, PhpstanConfigExistsFact)
+		})
+	}
+
+	t.Run("PhpstanConfigExistsFact is false", func(t *testing.T) {
+		d, err := os.MkdirTemp("", "test-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(d)
+		fact, err := PhpstanConfigExistsRule(d, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.NotEqual(t, fact, PhpstanConfigExistsFact)
+	})
+}
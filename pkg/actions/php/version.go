@@ -0,0 +1,86 @@
+package php
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"dagger.io/dagger"
+	"github.com/trustacks/trustacks/pkg/engine"
+)
+
+// defaultVersion is the php version used when the composer.json does not
+// require a php version.
+const defaultVersion = "8.3"
+
+// constraintPattern matches the major.minor version of a composer
+// version constraint.
+var constraintPattern = regexp.MustCompile(`(\d+)\.(\d+)`)
+
+// alternativePattern matches the separator of the constraint
+// alternatives (ie. ^7.4 || ^8.1).
+var alternativePattern = regexp.MustCompile(`\|\|?`)
+
+// composerJSON is the subset of the composer.json that requires the php
+// version.
+type composerJSON struct {
+	Require map[string]string `json:"require"`
+}
+
+// phpImage returns the php cli image of the version.
+func phpImage(version string) string {
+	return fmt.Sprintf("php:%s-cli", version)
+}
+
+// constraintVersion returns the major.minor lower bound of the newest
+// alternative of the php constraint (ie. ^7.4 || ^8.1 => 8.1).
+func constraintVersion(constraint string) string {
+	version, major, minor := "", -1, -1
+	for _, alternative := range alternativePattern.Split(constraint, -1) {
+		match := constraintPattern.FindStringSubmatch(alternative)
+		if match == nil {
+			continue
+		}
+		altMajor, _ := strconv.Atoi(match[1])
+		altMinor, _ := strconv.Atoi(match[2])
+		if altMajor > major || (altMajor == major && altMinor > minor) {
+			version, major, minor = match[0], altMajor, altMinor
+		}
+	}
+	return version
+}
+
+// sourceVersion returns the php version of the composer.json php
+// requirement. The default version is returned if the version is not
+// required.
+func sourceVersion(data []byte) (string, error) {
+	var config composerJSON
+	if err := json.Unmarshal(data, &config); err != nil {
+		return "", err
+	}
+	if version := constraintVersion(strings.TrimSpace(config.Require["php"])); version != "" {
+		return version, nil
+	}
+	return defaultVersion, nil
+}
+
+// withComposer initializes the container from the php image of the
+// composer.json php version and installs composer.
+func withComposer(container *dagger.Container) (*dagger.Container, error) {
+	data, err := engine.ContainerFileReader(container)("composer.json")
+	if err != nil {
+		return nil, err
+	}
+	version, err := sourceVersion(data)
+	if err != nil {
+		return nil, err
+	}
+	if version != defaultVersion {
+		container = engine.WithImage(container, fmt.Sprintf("php-%s", version), phpImage(version))
+	}
+	return container.
+		WithExec([]string{"sh", "-c", "apt-get update && apt-get install -y --no-install-recommends git unzip"}).
+		WithExec([]string{"sh", "-c", "curl -sS https://getcomposer.org/installer | php -- --install-dir=/usr/local/bin --filename=composer"}), nil
+}
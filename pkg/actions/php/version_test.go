@@ -0,0 +1,29 @@
+package php
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConstraintVersion(t *testing.T) {
+	assert.Equal(t, "8.2", constraintVersion("^8.2"))
+	assert.Equal(t, "8.1", constraintVersion(">=8.1 <8.4"))
+	assert.Equal(t, "8.1", constraintVersion("^7.4 || ^8.1"))
+	assert.Equal(t, "8.0", constraintVersion("~7.4.0|8.0.*"))
+	assert.Equal(t, "", constraintVersion("*"))
+}
+
+func TestSourceVersion(t *testing.T) {
+	version, err := sourceVersion([]byte(`{"require": {"php": "^8.2", "laravel/framework": "^11.0"}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "8.2", version)
+
+	version, err = sourceVersion([]byte(`{"require": {"monolog/monolog": "^3.0"}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, defaultVersion, version)
+}
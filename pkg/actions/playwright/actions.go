@@ -0,0 +1,48 @@
+package playwright
+
+import (
+	"dagger.io/dagger"
+	"github.com/trustacks/trustacks/pkg/actions/javascript"
+	"github.com/trustacks/trustacks/pkg/engine"
+)
+
+var playwrightTestAction = &engine.Action{
+	Name:        "playwrightTest",
+	DisplayName: "Playwright Test",
+	Description: "Run the end-to-end test suite against the built application with playwright.",
+	Image:       func(_ *engine.Config) string { return "node:lts" },
+	Stage:       engine.AcceptanceStage,
+	Caches:      append([]string{"/root/.cache/ms-playwright"}, javascript.Caches...),
+	OptionalInputArtifacts: []engine.Artifact{
+		engine.ContainerImageArtifact,
+		engine.BuildArtifact,
+	},
+	Script: func(container *dagger.Container, _ map[string]interface{}, utils *engine.ActionUtilities) error {
+		container, manager, err := javascript.InstallDependencies(container)
+		if err != nil {
+			return err
+		}
+		// install the browsers of the project playwright version.
+		container = container.WithExec(javascript.ExecArgs(manager, "playwright", "install", "--with-deps"))
+		container, url, err := javascript.WithAppService(container, utils)
+		if err != nil {
+			return err
+		}
+		container = container.
+			WithEnvVariable("CI", "true").
+			WithEnvVariable("BASE_URL", url).
+			WithEnvVariable("PLAYWRIGHT_BASE_URL", url)
+		return javascript.RunE2E(
+			container,
+			javascript.ExecArgs(manager, "playwright", "test"),
+			[]string{"test-results", "playwright-report"},
+			"playwright",
+			utils,
+		)
+	},
+	AdmissionCriteria: []engine.Fact{PlaywrightConfigExistsFact},
+}
+
+func init() {
+	engine.RegisterAction(playwrightTestAction)
+}
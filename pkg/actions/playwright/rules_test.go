@@ -0,0 +1,49 @@
+package playwright
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlaywrightConfigExistsRule(t *testing.T) {
+	t.Run("PlaywrightConfigExistsFact is true", func(t *testing.T) {
+		tempDirs := []string{}
+		defer func() {
+			for _, d := range tempDirs {
+				os.RemoveAll(d)
+			}
+		}()
+		for _, ext := range []string{"ts", "js", "mjs", "cjs"} {
+			d, err := os.MkdirTemp("", "test-src")
+			if err != nil {
+				t.Fatal(err)
+			}
+			tempDirs = append(tempDirs, d)
+			if err := os.WriteFile(filepath.Join(d, fmt.Sprintf("playwright.config.%s", ext)), []byte(""), 0744); err != nil {
+				t.Fatal(err)
+			}
+			fact, err := PlaywrightConfigExistsRule(d, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, fact, PlaywrightConfigExistsFact)
+		}
+	})
+
+	t.Run("PlaywrightConfigExistsFact is false", func(t *testing.T) {
+		d, err := os.MkdirTemp("", "test-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(d)
+		fact, err := PlaywrightConfigExistsRule(d, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.NotEqual(t, fact, PlaywrightConfigExistsFact)
+	})
+}
@@ -21,23 +21,37 @@ var pytestRunAction = &engine.Action{
 		}
 		return "python"
 	},
-	Stage: engine.CommitStage,
+	Stage:  engine.CommitStage,
+	Caches: python.Caches,
+	OutputArtifacts: []engine.Artifact{
+		engine.CoverageArtifact,
+	},
 	Script: func(container *dagger.Container, _ map[string]interface{}, utils *engine.ActionUtilities) error {
 		config := utils.GetConfig()
-		container = container.WithExec([]string{"apt", "update"})
-		container = container.WithExec([]string{"apt", "install", "gcc", "-y"})
-		container = container.WithExec([]string{"apt", "install", "-y", strings.Join(config.Python.Libraries, " ")})
-		container, err := python.InstallPythonDependencies(container)
-		if err != nil {
-			return err
-		}
-		if config.Python.DevRequirements != "" {
-			container = container.WithExec([]string{"pip", "install", "-r", config.Python.DevRequirements})
-		}
-		container = container.WithExec([]string{"pip", "install", "pytest"})
-		container = container.WithExec([]string{"pytest"})
-		_, err = container.Sync(context.Background())
-		return err
+		return python.RunMatrix(container, utils, func(container *dagger.Container, _ string, primary bool) error {
+			container = container.WithExec([]string{"apt", "update"})
+			container = container.WithExec([]string{"apt", "install", "gcc", "-y"})
+			container = container.WithExec([]string{"apt", "install", "-y", strings.Join(config.Python.Libraries, " ")})
+			container, _, err := python.InstallPythonDependencies(container)
+			if err != nil {
+				return err
+			}
+			if config.Python.DevRequirements != "" {
+				container = container.WithExec([]string{"pip", "install", "-r", config.Python.DevRequirements})
+			}
+			container = container.WithExec([]string{"pip", "install", "pytest"})
+			// coverage is reported if pytest-cov or coverage is a dependency.
+			tools, err := python.ContainerCoverageTools(container)
+			if err != nil {
+				return err
+			}
+			container = container.WithExec(tools.PytestArgs())
+			if !primary {
+				_, err := container.Sync(context.Background())
+				return err
+			}
+			return python.ExportCoverage(container, utils)
+		})
 	},
 	AdmissionCriteria: []engine.Fact{PytestDependencyExistsFact},
 	ExclusionCriteria: []engine.Fact{tox.ToxIniExistsFact},
@@ -2,41 +2,120 @@ package python
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"dagger.io/dagger"
+	"github.com/mitchellh/mapstructure"
+	"github.com/trustacks/trustacks/pkg/actions/dotnet"
+	"github.com/trustacks/trustacks/pkg/actions/golang"
+	"github.com/trustacks/trustacks/pkg/actions/java"
+	"github.com/trustacks/trustacks/pkg/actions/npm"
+	"github.com/trustacks/trustacks/pkg/actions/rust"
+	"github.com/trustacks/trustacks/pkg/engine"
 )
 
-func InstallPythonDependencies(container *dagger.Container) (*dagger.Container, error) {
-	entries, err := container.Directory("/src").Entries(context.Background())
-	if err != nil {
-		return container, err
+// buildExclusions are the facts that admit the other build artifact
+// producers. The wheels are not built or published when the build
+// artifact is produced by another build (ie. a python backend with a
+// javascript frontend).
+var buildExclusions = []engine.Fact{
+	golang.GolangCmdExistsFact,
+	java.MavenProjectExistsFact,
+	java.GradleProjectExistsFact,
+	dotnet.DotnetExecutableExistsFact,
+	npm.NpmBuildExistsFact,
+	rust.CargoBinaryExistsFact,
+}
+
+// defaultIndexURL is the pypi upload url.
+const defaultIndexURL = "https://upload.pypi.org/legacy/"
+
+// indexURL returns the configured package index upload url.
+func indexURL(config *engine.Config) string {
+	if config.Python.IndexURL != "" {
+		return config.Python.IndexURL
+	}
+	return defaultIndexURL
+}
+
+// Image returns the python image of the configured version.
+func Image(config *engine.Config) string {
+	if config.Python.Version != "" {
+		return fmt.Sprintf("python:%s", config.Python.Version)
 	}
-	installed := false
-	container = container.WithExec([]string{"pip", "install", "--upgrade", "pip"})
-EntriesLoop:
-	for _, entry := range entries {
-		if installed {
-			break
+	return "python"
+}
+
+// withPublish uploads the wheels and sdists in the dist directory to the
+// package index with the api token.
+func withPublish(container *dagger.Container, dist, url string, token *dagger.Secret) *dagger.Container {
+	return container.
+		WithExec([]string{"pip", "install", "twine"}).
+		WithExec([]string{"/bin/sh", "-c", fmt.Sprintf("twine check --strict %s/*", dist)}).
+		WithEnvVariable("TWINE_USERNAME", "__token__").
+		WithSecretVariable("TWINE_PASSWORD", token).
+		WithExec([]string{"/bin/sh", "-c", fmt.Sprintf("twine upload --non-interactive --repository-url '%s' %s/*", url, dist)})
+}
+
+var pythonBuild = &engine.Action{
+	Name:        "pythonBuild",
+	DisplayName: "Python Build",
+	Description: "Build the python wheel and sdist.",
+	Image:       Image,
+	Stage:       engine.OnDemand,
+	Caches:      Caches,
+	OutputArtifacts: []engine.Artifact{
+		engine.BuildArtifact,
+	},
+	Script: func(container *dagger.Container, _ map[string]interface{}, utils *engine.ActionUtilities) error {
+		config := utils.GetConfig()
+		container = container.WithExec([]string{"apt", "update"})
+		container = container.WithExec([]string{"apt", "install", "gcc", "-y"})
+		container = container.WithExec([]string{"apt", "install", "-y", strings.Join(config.Python.Libraries, " ")})
+		container = container.
+			WithExec([]string{"pip", "install", "build"}).
+			WithExec([]string{"python", "-m", "build", "--outdir", "/tmp/dist"})
+		return utils.Export(container, engine.BuildArtifact, "/tmp/dist")
+	},
+	AdmissionCriteria: []engine.Fact{PyProjectBuildSystemExistsFact},
+	ExclusionCriteria: buildExclusions,
+}
+
+var pythonPublish = &engine.Action{
+	Name:        "pythonPublish",
+	DisplayName: "Python Publish",
+	Description: "Publish the python wheel and sdist to the package index.",
+	Image:       Image,
+	Stage:       engine.ReleaseStage,
+	Caches:      Caches,
+	InputArtifacts: []engine.Artifact{
+		engine.BuildArtifact,
+	},
+	Script: func(container *dagger.Container, inputs map[string]interface{}, utils *engine.ActionUtilities) error {
+		args := struct {
+			PYPI_TOKEN string //nolint:revive,stylecheck
+		}{}
+		if err := mapstructure.Decode(inputs, &args); err != nil {
+			return err
 		}
-		switch entry {
-		case "poetry.lock":
-			container = container.
-				WithExec([]string{"pip", "install", "poetry"}).
-				WithExec([]string{"poetry", "install"})
-			break EntriesLoop
-		case "pdm.lock":
-			container = container.
-				WithExec([]string{"/bin/sh", "-c", "curl -sSL https://pdm-project.org/install-pdm.py | python -"}).
-				WithExec([]string{"/root/.local/bin/pdm", "install", "-d"})
-		case "requirements.txt":
-			container = container.
-				WithEnvVariable("XDG_CACHE_HOME", "/src/.cache/pip").
-				WithExec([]string{"pip", "install", "-r", "requirements.txt"})
-			break EntriesLoop
-		default:
-			continue
+		container, buildMount, err := utils.Mount(container, engine.BuildArtifact)
+		if err != nil {
+			return err
 		}
-		installed = true
-	}
-	return container, nil
+		token := utils.SetSecret("PYPI_TOKEN", args.PYPI_TOKEN)
+		container = withPublish(container, buildMount.Path("dist"), indexURL(utils.GetConfig()), token)
+		_, err = container.Sync(context.Background())
+		return err
+	},
+	Inputs: []engine.InputField{
+		engine.PypiToken,
+	},
+	AdmissionCriteria: []engine.Fact{PyProjectBuildSystemExistsFact},
+	ExclusionCriteria: buildExclusions,
+}
+
+func init() {
+	engine.RegisterAction(pythonBuild)
+	engine.RegisterAction(pythonPublish)
 }
@@ -0,0 +1,63 @@
+package python
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"dagger.io/dagger"
+	"github.com/stretchr/testify/assert"
+	"github.com/trustacks/trustacks/pkg/engine"
+)
+
+func TestIndexURL(t *testing.T) {
+	assert.Equal(t, "https://upload.pypi.org/legacy/", indexURL(&engine.Config{}))
+	config := &engine.Config{Python: engine.ConfigPython{IndexURL: "https://test.pypi.org/legacy/"}}
+	assert.Equal(t, "https://test.pypi.org/legacy/", indexURL(config))
+}
+
+func TestWithPublishIntegration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+	src, err := os.MkdirTemp("", "python-publish")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(src)
+	pyproject := `[build-system]
+requires = ["setuptools"]
+build-backend = "setuptools.build_meta"
+
+[project]
+name = "trustacks-publish-test"
+version = "0.1.0"
+`
+	if err := os.WriteFile(filepath.Join(src, "pyproject.toml"), []byte(pyproject), 0644); err != nil {
+		t.Fatal(err)
+	}
+	client, err := dagger.Connect(context.Background(), dagger.WithLogOutput(os.Stdout))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	// the package index accepts uploads without authentication.
+	index := client.Container().
+		From("pypiserver/pypiserver:v2.0.1").
+		WithExposedPort(8080).
+		WithExec([]string{"run", "-p", "8080", "-a", ".", "-P", ".", "/data/packages"}).
+		AsService()
+	container := client.Container().
+		From("python").
+		WithMountedDirectory("/src", client.Host().Directory(src)).
+		WithWorkdir("/src").
+		WithServiceBinding("pypi", index).
+		WithExec([]string{"pip", "install", "build"}).
+		WithExec([]string{"python", "-m", "build", "--outdir", "/tmp/dist"})
+	container = withPublish(container, "/tmp/dist", "http://pypi:8080", client.SetSecret("PYPI_TOKEN", "token"))
+	container = container.WithExec([]string{"pip", "download", "--no-deps", "--index-url", "http://pypi:8080/simple/", "-d", "/tmp/download", "trustacks-publish-test==0.1.0"})
+	if _, err := container.Sync(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
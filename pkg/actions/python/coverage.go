@@ -0,0 +1,120 @@
+package python
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"dagger.io/dagger"
+	"github.com/trustacks/trustacks/pkg/engine"
+)
+
+// coverageReport is the name of the cobertura coverage report.
+const coverageReport = "coverage.xml"
+
+// CoverageTools are the installed coverage tools of the container.
+type CoverageTools struct {
+	PytestCov bool
+	Coverage  bool
+}
+
+// ContainerCoverageTools returns the coverage tools that are installed
+// with the source dependencies.
+func ContainerCoverageTools(container *dagger.Container) (CoverageTools, error) {
+	out, err := container.
+		WithExec([]string{"/bin/sh", "-c", "pip show -q pytest-cov && echo pytest-cov; pip show -q coverage && echo coverage; true"}).
+		Stdout(context.Background())
+	if err != nil {
+		return CoverageTools{}, err
+	}
+	tools := CoverageTools{}
+	for _, line := range strings.Fields(out) {
+		switch line {
+		case "pytest-cov":
+			tools.PytestCov = true
+		case "coverage":
+			tools.Coverage = true
+		}
+	}
+	return tools, nil
+}
+
+// PytestArgs returns the pytest command that writes the coverage report
+// with the available coverage tool.
+func (t CoverageTools) PytestArgs() []string {
+	switch {
+	case t.PytestCov:
+		return []string{"pytest", "--cov", "--cov-report", "xml:" + coverageReport}
+	case t.Coverage:
+		return []string{"/bin/sh", "-c", "coverage run -m pytest && coverage xml -o " + coverageReport}
+	}
+	return []string{"pytest"}
+}
+
+// parseCoverageRate returns the line coverage percentage of the
+// cobertura coverage report.
+func parseCoverageRate(data []byte) (float64, error) {
+	report := struct {
+		LineRate string `xml:"line-rate,attr"`
+	}{}
+	if err := xml.Unmarshal(data, &report); err != nil {
+		return 0, err
+	}
+	var rate float64
+	if _, err := fmt.Sscanf(report.LineRate, "%g", &rate); err != nil {
+		return 0, fmt.Errorf("invalid coverage line-rate '%s'", report.LineRate)
+	}
+	return rate * 100, nil //nolint:gomnd
+}
+
+// checkCoverageThreshold returns an error if the coverage is below the
+// threshold percentage.
+func checkCoverageThreshold(percent, threshold float64) error {
+	if threshold > 0 && percent < threshold {
+		return fmt.Errorf("total coverage %.1f%% is below the %.1f%% threshold", percent, threshold)
+	}
+	return nil
+}
+
+// checkMissingCoverage returns an error if the coverage report does not
+// exist and a coverage threshold is configured.
+func checkMissingCoverage(threshold float64) error {
+	if threshold > 0 {
+		return fmt.Errorf("the %.1f%% coverage threshold is configured but %s was not generated (ie. pytest-cov or coverage is not installed)", threshold, coverageReport)
+	}
+	return nil
+}
+
+// ExportCoverage exports the coverage.xml of the source root as the
+// coverage artifact and checks the configured coverage threshold. The
+// coverage is not exported if the report does not exist, which fails
+// the action if a threshold is configured.
+func ExportCoverage(container *dagger.Container, utils *engine.ActionUtilities) error {
+	entries, err := container.Directory("/src").Entries(context.Background())
+	if err != nil {
+		return err
+	}
+	if !engine.HasEntry(entries, coverageReport) {
+		if _, err := container.Sync(context.Background()); err != nil {
+			return err
+		}
+		return checkMissingCoverage(utils.GetConfig().Python.CoverageThreshold)
+	}
+	report, err := container.File("/src/" + coverageReport).Contents(context.Background())
+	if err != nil {
+		return err
+	}
+	percent, err := parseCoverageRate([]byte(report))
+	if err != nil {
+		return err
+	}
+	utils.AddSummary(fmt.Sprintf("total: %.1f%%", percent))
+	if err := utils.Export(container, engine.CoverageArtifact, coverageReport); err != nil {
+		return err
+	}
+	if _, err := container.Sync(context.Background()); err != nil {
+		return err
+	}
+	return checkCoverageThreshold(percent, utils.GetConfig().Python.CoverageThreshold)
+}
@@ -0,0 +1,40 @@
+package python
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCoverageRate(t *testing.T) {
+	percent, err := parseCoverageRate([]byte(`<?xml version="1.0" ?>
+<coverage version="7.3.2" timestamp="1700000000000" lines-valid="200" lines-covered="171" line-rate="0.855" branches-covered="0" branches-valid="0" branch-rate="0" complexity="0">
+	<sources>
+		<source>/src</source>
+	</sources>
+</coverage>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.InDelta(t, 85.5, percent, 0.001)
+
+	_, err = parseCoverageRate([]byte(`<coverage></coverage>`))
+	assert.Error(t, err)
+}
+
+func TestCheckMissingCoverage(t *testing.T) {
+	assert.NoError(t, checkMissingCoverage(0))
+	assert.ErrorContains(t, checkMissingCoverage(80), "coverage.xml was not generated")
+}
+
+func TestCheckCoverageThreshold(t *testing.T) {
+	assert.NoError(t, checkCoverageThreshold(85.5, 0))
+	assert.NoError(t, checkCoverageThreshold(85.5, 80))
+	assert.Error(t, checkCoverageThreshold(85.5, 90))
+}
+
+func TestCoverageToolsPytestArgs(t *testing.T) {
+	assert.Equal(t, []string{"pytest", "--cov", "--cov-report", "xml:coverage.xml"}, CoverageTools{PytestCov: true, Coverage: true}.PytestArgs())
+	assert.Equal(t, []string{"/bin/sh", "-c", "coverage run -m pytest && coverage xml -o coverage.xml"}, CoverageTools{Coverage: true}.PytestArgs())
+	assert.Equal(t, []string{"pytest"}, CoverageTools{}.PytestArgs())
+}
@@ -0,0 +1,208 @@
+package python
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"dagger.io/dagger"
+	"github.com/bigkevmcd/go-configparser"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/trustacks/trustacks/pkg/engine"
+)
+
+var (
+	// toxFactorPattern matches the python version factors of tox
+	// environments (ie. py310 or py3.10).
+	toxFactorPattern = regexp.MustCompile(`^py(\d)\.?(\d+)$`)
+	// classifierPattern matches the python version trove classifiers.
+	classifierPattern = regexp.MustCompile(`^Programming Language :: Python :: (\d+\.\d+)$`)
+)
+
+// splitEnvList splits the tox envlist on commas and newlines outside of
+// braces.
+func splitEnvList(envlist string) []string {
+	envs := []string{}
+	depth, start := 0, 0
+	for i, c := range envlist + "," {
+		switch c {
+		case '{':
+			depth++
+		case '}':
+			depth--
+		case ',', '\n':
+			if depth > 0 {
+				continue
+			}
+			if env := strings.TrimSpace(envlist[start:i]); env != "" {
+				envs = append(envs, env)
+			}
+			start = i + 1
+		}
+	}
+	return envs
+}
+
+// expandEnv expands the brace groups of the tox environment name
+// (ie. py{39,310}-django -> py39-django, py310-django).
+func expandEnv(env string) []string {
+	open := strings.Index(env, "{")
+	end := strings.Index(env, "}")
+	if open < 0 || end < open {
+		return []string{env}
+	}
+	envs := []string{}
+	for _, value := range strings.Split(env[open+1:end], ",") {
+		envs = append(envs, expandEnv(env[:open]+strings.TrimSpace(value)+env[end+1:])...)
+	}
+	return envs
+}
+
+// ToxEnvironments returns the expanded environment names of the tox.ini
+// envlist.
+func ToxEnvironments(data []byte) ([]string, error) {
+	cfg, err := configparser.ParseReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	if !cfg.HasSection("tox") {
+		return nil, nil
+	}
+	ok, err := cfg.HasOption("tox", "envlist")
+	if err != nil || !ok {
+		return nil, err
+	}
+	envlist, err := cfg.Get("tox", "envlist")
+	if err != nil {
+		return nil, err
+	}
+	envs := []string{}
+	for _, env := range splitEnvList(envlist) {
+		envs = append(envs, expandEnv(env)...)
+	}
+	return envs, nil
+}
+
+// ToxEnvironmentVersion returns the python version of the tox
+// environment factors (ie. py311-django -> 3.11). An empty string is
+// returned if the environment has no version factor.
+func ToxEnvironmentVersion(env string) string {
+	for _, factor := range strings.Split(env, "-") {
+		if match := toxFactorPattern.FindStringSubmatch(factor); match != nil {
+			return match[1] + "." + match[2]
+		}
+	}
+	return ""
+}
+
+// toxVersions returns the python versions of the tox envlist.
+func toxVersions(data []byte) ([]string, error) {
+	envs, err := ToxEnvironments(data)
+	if err != nil {
+		return nil, err
+	}
+	versions := []string{}
+	for _, env := range envs {
+		if version := ToxEnvironmentVersion(env); version != "" {
+			versions = appendVersion(versions, version)
+		}
+	}
+	return versions, nil
+}
+
+// classifierVersions returns the python versions of the pyproject.toml
+// trove classifiers.
+func classifierVersions(data []byte) ([]string, error) {
+	pyproject := struct {
+		Project struct {
+			Classifiers []string `toml:"classifiers"`
+		} `toml:"project"`
+	}{}
+	if err := toml.Unmarshal(data, &pyproject); err != nil {
+		return nil, err
+	}
+	versions := []string{}
+	for _, classifier := range pyproject.Project.Classifiers {
+		if match := classifierPattern.FindStringSubmatch(strings.TrimSpace(classifier)); match != nil {
+			versions = appendVersion(versions, match[1])
+		}
+	}
+	return versions, nil
+}
+
+// appendVersion appends the version if it is not in the versions.
+func appendVersion(versions []string, version string) []string {
+	for _, v := range versions {
+		if v == version {
+			return versions
+		}
+	}
+	return append(versions, version)
+}
+
+// matrixVersions returns the python versions of the test matrix from the
+// configuration, the tox envlist or the pyproject.toml classifiers in
+// that order. The configured version is used if there is no matrix.
+func matrixVersions(config *engine.Config, entries []string, readFile engine.FileReader) ([]string, error) {
+	if len(config.Python.Versions) > 0 {
+		return config.Python.Versions, nil
+	}
+	sources := []struct {
+		name   string
+		parser func([]byte) ([]string, error)
+	}{
+		{"tox.ini", toxVersions},
+		{"pyproject.toml", classifierVersions},
+	}
+	for _, source := range sources {
+		if !engine.HasEntry(entries, source.name) {
+			continue
+		}
+		data, err := readFile(source.name)
+		if err != nil {
+			return nil, err
+		}
+		versions, err := source.parser(data)
+		if err != nil {
+			return nil, err
+		}
+		if len(versions) > 0 {
+			return versions, nil
+		}
+	}
+	return []string{config.Python.Version}, nil
+}
+
+// RunMatrix runs the function in a container of each python version of
+// the test matrix. The function is run in the action container with the
+// configured version if the matrix has a single version. The primary
+// argument is true for the first version, which exports the version
+// independent artifacts (ie. coverage).
+func RunMatrix(container *dagger.Container, utils *engine.ActionUtilities, fn func(container *dagger.Container, version string, primary bool) error) error {
+	config := utils.GetConfig()
+	entries, err := container.Directory("/src").Entries(context.Background())
+	if err != nil {
+		return err
+	}
+	versions, err := matrixVersions(config, entries, engine.ContainerFileReader(container))
+	if err != nil {
+		return err
+	}
+	if len(versions) == 1 && versions[0] == config.Python.Version {
+		return fn(container, versions[0], true)
+	}
+	errs := []error{}
+	for i, version := range versions {
+		versionContainer := engine.WithImage(container, fmt.Sprintf("python-%s", version), fmt.Sprintf("python:%s", version))
+		status := "passed"
+		if err := fn(versionContainer, version, i == 0); err != nil {
+			status = "failed"
+			errs = append(errs, fmt.Errorf("python %s: %w", version, err))
+		}
+		utils.AddSummary(fmt.Sprintf("python %s: %s", version, status))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,93 @@
+package python
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustacks/trustacks/pkg/engine"
+)
+
+func TestSplitEnvList(t *testing.T) {
+	assert.Equal(t, []string{"py{39,310}-django", "lint", "py311"}, splitEnvList("py{39,310}-django, lint\npy311"))
+}
+
+func TestExpandEnv(t *testing.T) {
+	assert.Equal(t, []string{"py39-django", "py310-django"}, expandEnv("py{39,310}-django"))
+	assert.Equal(t, []string{"py39-a", "py39-b", "py310-a", "py310-b"}, expandEnv("py{39,310}-{a,b}"))
+	assert.Equal(t, []string{"lint"}, expandEnv("lint"))
+}
+
+func TestToxEnvironments(t *testing.T) {
+	envs, err := ToxEnvironments([]byte("[tox]\nenvlist = py{39,310}-django, lint\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"py39-django", "py310-django", "lint"}, envs)
+}
+
+func TestToxEnvironmentVersion(t *testing.T) {
+	assert.Equal(t, "3.10", ToxEnvironmentVersion("py310-django"))
+	assert.Equal(t, "3.11", ToxEnvironmentVersion("django-py3.11"))
+	assert.Equal(t, "", ToxEnvironmentVersion("lint"))
+	assert.Equal(t, "", ToxEnvironmentVersion("py"))
+	assert.Equal(t, "", ToxEnvironmentVersion("pypy3"))
+}
+
+func TestToxVersions(t *testing.T) {
+	versions, err := toxVersions([]byte("[tox]\nenvlist =\n    py{39,310}-django\n    py3.11\n    lint\n    pypy3\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"3.9", "3.10", "3.11"}, versions)
+
+	versions, err = toxVersions([]byte("[testenv]\ncommands = pytest\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Empty(t, versions)
+}
+
+func TestClassifierVersions(t *testing.T) {
+	versions, err := classifierVersions([]byte(`[project]
+name = "app"
+classifiers = [
+    "Programming Language :: Python :: 3",
+    "Programming Language :: Python :: 3.11",
+    "Programming Language :: Python :: 3.12",
+    "License :: OSI Approved :: MIT License",
+]
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"3.11", "3.12"}, versions)
+}
+
+func TestMatrixVersions(t *testing.T) {
+	files := map[string]string{
+		"tox.ini":        "[tox]\nenvlist = py310,py311\n",
+		"pyproject.toml": "[project]\nclassifiers = [\"Programming Language :: Python :: 3.12\"]\n",
+	}
+	readFile := func(name string) ([]byte, error) { return []byte(files[name]), nil }
+	tests := []struct {
+		name     string
+		config   *engine.Config
+		entries  []string
+		versions []string
+	}{
+		{"configured versions", &engine.Config{Python: engine.ConfigPython{Versions: []string{"3.9", "3.10"}}}, []string{"tox.ini"}, []string{"3.9", "3.10"}},
+		{"tox envlist", &engine.Config{}, []string{"tox.ini", "pyproject.toml"}, []string{"3.10", "3.11"}},
+		{"classifiers", &engine.Config{}, []string{"pyproject.toml"}, []string{"3.12"}},
+		{"configured version", &engine.Config{Python: engine.ConfigPython{Version: "3.11"}}, []string{"requirements.txt"}, []string{"3.11"}},
+		{"default", &engine.Config{}, nil, []string{""}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			versions, err := matrixVersions(test.config, test.entries, readFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, test.versions, versions)
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package python
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"dagger.io/dagger"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/trustacks/trustacks/pkg/engine"
+)
+
+const (
+	Pip    = "pip"
+	Poetry = "poetry"
+	Pdm    = "pdm"
+	Uv     = "uv"
+	Pipenv = "pipenv"
+	Hatch  = "hatch"
+)
+
+// Caches are the dependency cache paths of the supported package
+// managers.
+var Caches = []string{
+	"/root/.cache/pip",
+	"/root/.cache/pypoetry",
+	"/root/.cache/pdm",
+	"/root/.cache/uv",
+	"/root/.cache/hatch",
+}
+
+// lockfiles maps the package manager lockfiles to their package manager
+// in order of precedence.
+var lockfiles = []struct {
+	name    string
+	manager string
+}{
+	{"uv.lock", Uv},
+	{"poetry.lock", Poetry},
+	{"pdm.lock", Pdm},
+	{"Pipfile.lock", Pipenv},
+}
+
+// pyprojectTOML is the subset of the pyproject.toml that identifies the
+// package manager.
+type pyprojectTOML struct {
+	Project map[string]interface{} `toml:"project"`
+	Tool    struct {
+		Poetry map[string]interface{} `toml:"poetry"`
+		Pdm    map[string]interface{} `toml:"pdm"`
+		Uv     map[string]interface{} `toml:"uv"`
+		Hatch  map[string]interface{} `toml:"hatch"`
+	} `toml:"tool"`
+}
+
+// detectPackageManager returns the package manager from the lockfiles,
+// the Pipfile, the pyproject.toml tool tables, the requirements.txt and
+// the PEP 621 project table in that order. An empty string is returned
+// if the source is not a python project.
+func detectPackageManager(pyproject []byte, entries []string) (string, error) {
+	for _, lockfile := range lockfiles {
+		if engine.HasEntry(entries, lockfile.name) {
+			return lockfile.manager, nil
+		}
+	}
+	if engine.HasEntry(entries, "Pipfile") {
+		return Pipenv, nil
+	}
+	var project pyprojectTOML
+	if pyproject != nil {
+		if err := toml.Unmarshal(pyproject, &project); err != nil {
+			return "", err
+		}
+	}
+	switch {
+	case project.Tool.Poetry != nil:
+		return Poetry, nil
+	case project.Tool.Pdm != nil:
+		return Pdm, nil
+	case project.Tool.Uv != nil:
+		return Uv, nil
+	case project.Tool.Hatch != nil || engine.HasEntry(entries, "hatch.toml"):
+		return Hatch, nil
+	case engine.HasEntry(entries, "requirements.txt"):
+		return Pip, nil
+	case project.Project != nil:
+		return Pip, nil
+	}
+	return "", nil
+}
+
+// installArgs returns the commands that install the source dependencies
+// into the system python environment with the package manager. Locked
+// dependencies are installed without updating the lockfile.
+func installArgs(manager string, entries []string) [][]string {
+	switch manager {
+	case Uv:
+		// the sync is inexact so that the packages of the system
+		// environment that are not in the lockfile (ie. pip and uv) are
+		// not uninstalled.
+		if engine.HasEntry(entries, "uv.lock") {
+			return [][]string{{"pip", "install", "uv"}, {"uv", "sync", "--inexact", "--frozen"}}
+		}
+		return [][]string{{"pip", "install", "uv"}, {"uv", "sync", "--inexact"}}
+	case Poetry:
+		return [][]string{{"pip", "install", "poetry"}, {"poetry", "install"}}
+	case Pdm:
+		return [][]string{
+			{"pip", "install", "pdm"},
+			{"pdm", "export", "--without-hashes", "-o", "/tmp/requirements.txt"},
+			{"pip", "install", "-r", "/tmp/requirements.txt"},
+		}
+	case Pipenv:
+		if engine.HasEntry(entries, "Pipfile.lock") {
+			return [][]string{{"pip", "install", "pipenv"}, {"pipenv", "install", "--system", "--dev", "--deploy"}}
+		}
+		return [][]string{{"pip", "install", "pipenv"}, {"pipenv", "install", "--system", "--dev", "--skip-lock"}}
+	case Hatch:
+		return [][]string{
+			{"pip", "install", "hatch"},
+			{"/bin/sh", "-c", "hatch dep show requirements > /tmp/requirements.txt"},
+			{"pip", "install", "-r", "/tmp/requirements.txt"},
+			{"pip", "install", "-e", "."},
+		}
+	case Pip:
+		if engine.HasEntry(entries, "requirements.txt") {
+			return [][]string{{"pip", "install", "-r", "requirements.txt"}}
+		}
+		// PEP 621 projects are installed from the pyproject.toml.
+		return [][]string{{"pip", "install", "."}}
+	}
+	return nil
+}
+
+// sourcePackageManager detects the package manager of the source
+// directory.
+func sourcePackageManager(source string) (string, error) {
+	dirEntries, err := os.ReadDir(source)
+	if err != nil {
+		return "", err
+	}
+	entries := []string{}
+	for _, entry := range dirEntries {
+		entries = append(entries, entry.Name())
+	}
+	var pyproject []byte
+	if engine.HasEntry(entries, "pyproject.toml") {
+		pyproject, err = os.ReadFile(filepath.Join(source, "pyproject.toml"))
+		if err != nil {
+			return "", err
+		}
+	}
+	return detectPackageManager(pyproject, entries)
+}
+
+// InstallPythonDependencies installs the source dependencies with the
+// detected package manager and returns the package manager.
+func InstallPythonDependencies(container *dagger.Container) (*dagger.Container, string, error) {
+	entries, err := container.Directory("/src").Entries(context.Background())
+	if err != nil {
+		return container, "", err
+	}
+	var pyproject []byte
+	if engine.HasEntry(entries, "pyproject.toml") {
+		contents, err := container.File("/src/pyproject.toml").Contents(context.Background())
+		if err != nil {
+			return container, "", err
+		}
+		pyproject = []byte(contents)
+	}
+	manager, err := detectPackageManager(pyproject, entries)
+	if err != nil {
+		return container, "", err
+	}
+	container = container.WithExec([]string{"pip", "install", "--upgrade", "pip"})
+	// install the dependencies into the system environment so that they
+	// are available to the tools installed with pip.
+	container = container.
+		WithEnvVariable("POETRY_VIRTUALENVS_CREATE", "false").
+		WithEnvVariable("UV_PROJECT_ENVIRONMENT", "/usr/local")
+	for _, args := range installArgs(manager, entries) {
+		container = container.WithExec(args)
+	}
+	return container, manager, nil
+}
@@ -0,0 +1,103 @@
+package python
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"dagger.io/dagger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectPackageManager(t *testing.T) {
+	tests := []struct {
+		name      string
+		pyproject string
+		entries   []string
+		manager   string
+	}{
+		{"none", ``, []string{"main.py"}, ""},
+		{"requirements", ``, []string{"requirements.txt"}, Pip},
+		{"pep 621", "[project]\nname = \"app\"\n", []string{"pyproject.toml"}, Pip},
+		{"requirements over pep 621", "[project]\nname = \"app\"\n", []string{"pyproject.toml", "requirements.txt"}, Pip},
+		{"poetry lockfile", ``, []string{"poetry.lock", "requirements.txt"}, Poetry},
+		{"poetry tool", "[tool.poetry]\nname = \"app\"\n", []string{"pyproject.toml"}, Poetry},
+		{"pdm lockfile", ``, []string{"pdm.lock"}, Pdm},
+		{"uv lockfile", ``, []string{"uv.lock"}, Uv},
+		{"uv tool", "[project]\nname = \"app\"\n[tool.uv]\ndev-dependencies = []\n", []string{"pyproject.toml"}, Uv},
+		{"pipenv", ``, []string{"Pipfile", "requirements.txt"}, Pipenv},
+		{"hatch tool", "[project]\nname = \"app\"\n[tool.hatch.envs.default]\n", []string{"pyproject.toml"}, Hatch},
+		{"hatch config", ``, []string{"hatch.toml", "requirements.txt"}, Hatch},
+		{"lockfile precedence", ``, []string{"Pipfile.lock", "pdm.lock", "poetry.lock", "uv.lock"}, Uv},
+		{"lockfile over tool", "[tool.poetry]\nname = \"app\"\n", []string{"pyproject.toml", "pdm.lock"}, Pdm},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var pyproject []byte
+			if test.pyproject != "" {
+				pyproject = []byte(test.pyproject)
+			}
+			manager, err := detectPackageManager(pyproject, test.entries)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, test.manager, manager)
+		})
+	}
+	_, err := detectPackageManager([]byte(`[project`), []string{"pyproject.toml"})
+	assert.Error(t, err)
+}
+
+func TestInstallArgs(t *testing.T) {
+	assert.Equal(t, [][]string{{"pip", "install", "-r", "requirements.txt"}}, installArgs(Pip, []string{"requirements.txt"}))
+	assert.Equal(t, [][]string{{"pip", "install", "."}}, installArgs(Pip, []string{"pyproject.toml"}))
+	assert.Equal(t, []string{"uv", "sync", "--inexact", "--frozen"}, installArgs(Uv, []string{"uv.lock"})[1])
+	assert.Equal(t, []string{"uv", "sync", "--inexact"}, installArgs(Uv, nil)[1])
+	assert.Equal(t, []string{"pipenv", "install", "--system", "--dev", "--deploy"}, installArgs(Pipenv, []string{"Pipfile", "Pipfile.lock"})[1])
+	assert.Equal(t, []string{"pipenv", "install", "--system", "--dev", "--skip-lock"}, installArgs(Pipenv, []string{"Pipfile"})[1])
+	assert.Nil(t, installArgs("", nil))
+}
+
+func TestInstallPythonDependenciesUvIntegration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+	src, err := os.MkdirTemp("", "python-uv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(src)
+	pyproject := `[project]
+name = "app"
+version = "0.1.0"
+dependencies = ["six"]
+
+[tool.uv]
+package = false
+`
+	if err := os.WriteFile(filepath.Join(src, "pyproject.toml"), []byte(pyproject), 0644); err != nil {
+		t.Fatal(err)
+	}
+	client, err := dagger.Connect(context.Background(), dagger.WithLogOutput(os.Stdout))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	container := client.Container().
+		From("python").
+		WithMountedDirectory("/src", client.Host().Directory(src)).
+		WithWorkdir("/src")
+	container, manager, err := InstallPythonDependencies(container)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, Uv, manager)
+	// the tools are installed with pip after the dependencies.
+	container = container.
+		WithExec([]string{"pip", "install", "flake8"}).
+		WithExec([]string{"python", "-c", "import six"})
+	if _, err := container.Sync(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -14,8 +14,36 @@ import (
 var (
 	PyProjectTomlExistsFact   = engine.NewFact()
 	PipRequirementsExistsFact = engine.NewFact()
+	// PipPackageManagerFact is true if the source is installed with pip
+	// from the requirements.txt or the PEP 621 pyproject.toml.
+	PipPackageManagerFact = engine.NewFact()
+	// PoetryPackageManagerFact is true if the source is managed with
+	// poetry.
+	PoetryPackageManagerFact = engine.NewFact()
+	// PdmPackageManagerFact is true if the source is managed with pdm.
+	PdmPackageManagerFact = engine.NewFact()
+	// UvPackageManagerFact is true if the source is managed with uv.
+	UvPackageManagerFact = engine.NewFact()
+	// PipenvPackageManagerFact is true if the source is managed with
+	// pipenv.
+	PipenvPackageManagerFact = engine.NewFact()
+	// HatchPackageManagerFact is true if the source is managed with
+	// hatch.
+	HatchPackageManagerFact = engine.NewFact()
+	// PyProjectBuildSystemExistsFact is true if the pyproject.toml has a
+	// [build-system] table.
+	PyProjectBuildSystemExistsFact = engine.NewFact()
 )
 
+var packageManagerFacts = map[string]engine.Fact{
+	Pip:    PipPackageManagerFact,
+	Poetry: PoetryPackageManagerFact,
+	Pdm:    PdmPackageManagerFact,
+	Uv:     UvPackageManagerFact,
+	Pipenv: PipenvPackageManagerFact,
+	Hatch:  HatchPackageManagerFact,
+}
+
 var PyProjectTomlExistsRule engine.Rule = func(source string, _ engine.Collector, _ mapset.Set[engine.Fact]) (engine.Fact, error) {
 	var fact = engine.NilFact
 	if _, err := os.Stat(filepath.Join(source, "pyproject.toml")); os.IsNotExist(err) {
@@ -38,6 +66,52 @@ var PipRequirementsExistsRule engine.Rule = func(source string, _ engine.Collect
 	return fact, nil
 }
 
+// PackageManagerRule detects the python package manager from the source
+// lockfiles and project configuration.
+var PackageManagerRule engine.Rule = func(source string, _ engine.Collector, _ mapset.Set[engine.Fact]) (engine.Fact, error) {
+	var fact = engine.NilFact
+	manager, err := sourcePackageManager(source)
+	if err != nil {
+		return fact, err
+	}
+	if manager != "" {
+		fact = packageManagerFacts[manager]
+	}
+	return fact, nil
+}
+
+// HasBuildSystem returns true if the source pyproject.toml declares a
+// build backend that builds wheels and sdists.
+func HasBuildSystem(source string) (bool, error) {
+	data, err := os.ReadFile(filepath.Join(source, "pyproject.toml"))
+	if os.IsNotExist(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	pyproject := struct {
+		BuildSystem map[string]interface{} `toml:"build-system"`
+	}{}
+	if err := toml.Unmarshal(data, &pyproject); err != nil {
+		return false, err
+	}
+	return pyproject.BuildSystem != nil, nil
+}
+
+// PyProjectBuildSystemExistsRule checks if the pyproject.toml has a
+// [build-system] table.
+var PyProjectBuildSystemExistsRule engine.Rule = func(source string, _ engine.Collector, _ mapset.Set[engine.Fact]) (engine.Fact, error) {
+	var fact = engine.NilFact
+	ok, err := HasBuildSystem(source)
+	if err != nil {
+		return fact, err
+	}
+	if ok {
+		fact = PyProjectBuildSystemExistsFact
+	}
+	return fact, nil
+}
+
 type PoetryTOML struct {
 	Packages []PoetryTOMLPackage `toml:"package"`
 }
@@ -74,3 +148,8 @@ func CheckPipRequirements(path, dependency string) (bool, error) {
 	}
 	return re.Match(data), nil
 }
+
+func init() {
+	engine.AddToRuleset(&PackageManagerRule, nil)
+	engine.AddToRuleset(&PyProjectTomlExistsRule, &PyProjectBuildSystemExistsRule)
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/trustacks/trustacks/pkg/engine"
 )
 
 //nolint:dupl
@@ -71,3 +72,87 @@ func TestPipRequirementsExistsRule(t *testing.T) {
 		assert.NotEqual(t, fact, PipRequirementsExistsFact)
 	})
 }
+
+func TestPackageManagerRule(t *testing.T) {
+	t.Run("UvPackageManagerFact is true", func(t *testing.T) {
+		d, err := os.MkdirTemp("", "test-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(d)
+		if err := os.WriteFile(filepath.Join(d, "pyproject.toml"), []byte("[project]\nname = \"app\"\n"), 0744); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(d, "uv.lock"), []byte(``), 0744); err != nil {
+			t.Fatal(err)
+		}
+		fact, err := PackageManagerRule(d, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, fact, UvPackageManagerFact)
+	})
+
+	t.Run("PipPackageManagerFact is true", func(t *testing.T) {
+		d, err := os.MkdirTemp("", "test-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(d)
+		if err := os.WriteFile(filepath.Join(d, "requirements.txt"), []byte(``), 0744); err != nil {
+			t.Fatal(err)
+		}
+		fact, err := PackageManagerRule(d, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, fact, PipPackageManagerFact)
+	})
+
+	t.Run("PackageManagerFact is false", func(t *testing.T) {
+		d, err := os.MkdirTemp("", "test-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(d)
+		fact, err := PackageManagerRule(d, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, fact, engine.NilFact)
+	})
+}
+
+func TestPyProjectBuildSystemExistsRule(t *testing.T) {
+	t.Run("PyProjectBuildSystemExistsFact is true", func(t *testing.T) {
+		d, err := os.MkdirTemp("", "test-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(d)
+		if err := os.WriteFile(filepath.Join(d, "pyproject.toml"), []byte("[build-system]\nrequires = [\"setuptools\"]\nbuild-backend = \"setuptools.build_meta\"\n"), 0744); err != nil {
+			t.Fatal(err)
+		}
+		fact, err := PyProjectBuildSystemExistsRule(d, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, fact, PyProjectBuildSystemExistsFact)
+	})
+
+	t.Run("PyProjectBuildSystemExistsFact is false", func(t *testing.T) {
+		d, err := os.MkdirTemp("", "test-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(d)
+		if err := os.WriteFile(filepath.Join(d, "pyproject.toml"), []byte("[tool.black]\n"), 0744); err != nil {
+			t.Fatal(err)
+		}
+		fact, err := PyProjectBuildSystemExistsRule(d, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.NotEqual(t, fact, PyProjectBuildSystemExistsFact)
+	})
+}
@@ -0,0 +1,114 @@
+package ruby
+
+import (
+	"context"
+
+	"dagger.io/dagger"
+	"github.com/trustacks/trustacks/pkg/engine"
+)
+
+// caches are the bundler gem caches.
+var caches = []string{"/usr/local/bundle"}
+
+// minitestArgs returns the minitest command. Rails applications run the
+// tests with the rails test runner, and other projects with the rake
+// test task.
+func minitestArgs(binEntries []string) []string {
+	if engine.HasEntry(binEntries, "rails") {
+		return []string{"bin/rails", "test"}
+	}
+	return []string{"bundle", "exec", "rake", "test"}
+}
+
+// bundleInstall installs the Gemfile dependencies with the source ruby
+// version. The Gemfile.lock is not updated during the install if it
+// exists.
+func bundleInstall(container *dagger.Container, utils *engine.ActionUtilities) (*dagger.Container, error) {
+	container, err := withRuby(container)
+	if err != nil {
+		return nil, err
+	}
+	if utils.HasFact(GemfileLockExistsFact) {
+		container = container.WithEnvVariable("BUNDLE_FROZEN", "true")
+	}
+	return container.WithExec([]string{"bundle", "install", "--jobs", "4"}), nil
+}
+
+var rspecRun = &engine.Action{
+	Name:        "rspecRun",
+	DisplayName: "RSpec Run",
+	Description: "Run the ruby test suite with rspec.",
+	Image:       func(_ *engine.Config) string { return rubyImage(defaultVersion) },
+	Stage:       engine.CommitStage,
+	Caches:      caches,
+	Script: func(container *dagger.Container, _ map[string]interface{}, utils *engine.ActionUtilities) error {
+		container, err := bundleInstall(container, utils)
+		if err != nil {
+			return err
+		}
+		_, err = container.
+			WithEnvVariable("RAILS_ENV", "test").
+			WithExec([]string{"bundle", "exec", "rspec"}).
+			Sync(context.Background())
+		return err
+	},
+	AdmissionCriteria: []engine.Fact{RspecExistsFact},
+}
+
+var minitestRun = &engine.Action{
+	Name:        "minitestRun",
+	DisplayName: "Minitest Run",
+	Description: "Run the ruby test suite with minitest.",
+	Image:       func(_ *engine.Config) string { return rubyImage(defaultVersion) },
+	Stage:       engine.CommitStage,
+	Caches:      caches,
+	Script: func(container *dagger.Container, _ map[string]interface{}, utils *engine.ActionUtilities) error {
+		container, err := bundleInstall(container, utils)
+		if err != nil {
+			return err
+		}
+		entries, err := container.Directory("/src").Entries(context.Background())
+		if err != nil {
+			return err
+		}
+		binEntries := []string{}
+		if engine.HasEntry(entries, "bin") {
+			binEntries, err = container.Directory("/src/bin").Entries(context.Background())
+			if err != nil {
+				return err
+			}
+		}
+		_, err = container.
+			WithEnvVariable("RAILS_ENV", "test").
+			WithExec(minitestArgs(binEntries)).
+			Sync(context.Background())
+		return err
+	},
+	AdmissionCriteria: []engine.Fact{MinitestExistsFact},
+}
+
+var rubocopRun = &engine.Action{
+	Name:        "rubocopRun",
+	DisplayName: "RuboCop Run",
+	Description: "Lint the ruby source with rubocop.",
+	Image:       func(_ *engine.Config) string { return rubyImage(defaultVersion) },
+	Stage:       engine.CommitStage,
+	Caches:      caches,
+	Script: func(container *dagger.Container, _ map[string]interface{}, utils *engine.ActionUtilities) error {
+		container, err := bundleInstall(container, utils)
+		if err != nil {
+			return err
+		}
+		_, err = container.
+			WithExec([]string{"bundle", "exec", "rubocop", "--format", "progress"}).
+			Sync(context.Background())
+		return err
+	},
+	AdmissionCriteria: []engine.Fact{RubocopConfigExistsFact},
+}
+
+func init() {
+	engine.RegisterAction(rspecRun)
+	engine.RegisterAction(minitestRun)
+	engine.RegisterAction(rubocopRun)
+}
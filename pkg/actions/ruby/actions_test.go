@@ -0,0 +1,13 @@
+package ruby
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMinitestArgs(t *testing.T) {
+	assert.Equal(t, []string{"bin/rails", "test"}, minitestArgs([]string{"bundle", "rails", "setup"}))
+	assert.Equal(t, []string{"bundle", "exec", "rake", "test"}, minitestArgs([]string{"console"}))
+	assert.Equal(t, []string{"bundle", "exec", "rake", "test"}, minitestArgs(nil))
+}
@@ -0,0 +1,97 @@
+package ruby
+
+import (
+	mapset "github.com/deckarep/golang-set/v2"
+	"github.com/trustacks/trustacks/pkg/engine"
+)
+
+var (
+	// GemfileExistsFact is true if the Gemfile exists in the root of the
+	// application source.
+	GemfileExistsFact = engine.NewFact()
+	// GemfileLockExistsFact is true if the Gemfile.lock exists in the
+	// root of the application source.
+	GemfileLockExistsFact = engine.NewFact()
+	// RspecExistsFact is true if the .rspec options file or the
+	// spec/spec_helper.rb exists.
+	RspecExistsFact = engine.NewFact()
+	// MinitestExistsFact is true if the test/test_helper.rb exists.
+	MinitestExistsFact = engine.NewFact()
+	// RubocopConfigExistsFact is true if the .rubocop.yml exists.
+	RubocopConfigExistsFact = engine.NewFact()
+)
+
+// GemfileExistsRule checks if the Gemfile exists in the root of the
+// source.
+var GemfileExistsRule engine.Rule = func(source string, _ engine.Collector, _ mapset.Set[engine.Fact]) (engine.Fact, error) {
+	var fact = engine.NilFact
+	ok, err := engine.AnyFileExists(source, "Gemfile")
+	if err != nil {
+		return fact, err
+	}
+	if ok {
+		fact = GemfileExistsFact
+	}
+	return fact, nil
+}
+
+// GemfileLockExistsRule checks if the Gemfile.lock exists in the root of
+// the source.
+var GemfileLockExistsRule engine.Rule = func(source string, _ engine.Collector, _ mapset.Set[engine.Fact]) (engine.Fact, error) {
+	var fact = engine.NilFact
+	ok, err := engine.AnyFileExists(source, "Gemfile.lock")
+	if err != nil {
+		return fact, err
+	}
+	if ok {
+		fact = GemfileLockExistsFact
+	}
+	return fact, nil
+}
+
+// RspecExistsRule checks if the rspec options file or the spec helper
+// exists.
+var RspecExistsRule engine.Rule = func(source string, _ engine.Collector, _ mapset.Set[engine.Fact]) (engine.Fact, error) {
+	var fact = engine.NilFact
+	ok, err := engine.AnyFileExists(source, ".rspec", "spec/spec_helper.rb")
+	if err != nil {
+		return fact, err
+	}
+	if ok {
+		fact = RspecExistsFact
+	}
+	return fact, nil
+}
+
+// MinitestExistsRule checks if the minitest test helper exists.
+var MinitestExistsRule engine.Rule = func(source string, _ engine.Collector, _ mapset.Set[engine.Fact]) (engine.Fact, error) {
+	var fact = engine.NilFact
+	ok, err := engine.AnyFileExists(source, "test/test_helper.rb")
+	if err != nil {
+		return fact, err
+	}
+	if ok {
+		fact = MinitestExistsFact
+	}
+	return fact, nil
+}
+
+// RubocopConfigExistsRule checks if the rubocop configuration exists.
+var RubocopConfigExistsRule engine.Rule = func(source string, _ engine.Collector, _ mapset.Set[engine.Fact]) (engine.Fact, error) {
+	var fact = engine.NilFact
+	ok, err := engine.AnyFileExists(source, ".rubocop.yml")
+	if err != nil {
+		return fact, err
+	}
+	if ok {
+		fact = RubocopConfigExistsFact
+	}
+	return fact, nil
+}
+
+func init() {
+	engine.AddToRuleset(&GemfileExistsRule, &GemfileLockExistsRule)
+	engine.AddToRuleset(&GemfileExistsRule, &RspecExistsRule)
+	engine.AddToRuleset(&GemfileExistsRule, &MinitestExistsRule)
+	engine.AddToRuleset(&GemfileExistsRule, &RubocopConfigExistsRule)
+}
@@ -0,0 +1,172 @@
+package ruby
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGemfileExistsRule(t *testing.T) {
+	t.Run("GemfileExistsFact is true", func(t *testing.T) {
+		d, err := os.MkdirTemp("", "test-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(d)
+		if err := os.WriteFile(filepath.Join(d, "Gemfile"), []byte{}, 0644); err != nil {
+			t.Fatal(err)
+		}
+		fact, err := GemfileExistsRule(d, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, fact, GemfileExistsFact)
+	})
+
+	t.Run("GemfileExistsFact is false", func(t *testing.T) {
+		d, err := os.MkdirTemp("", "test-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(d)
+		fact, err := GemfileExistsRule(d, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.NotEqual(t, fact, GemfileExistsFact)
+	})
+}
+
+func TestGemfileLockExistsRule(t *testing.T) {
+	t.Run("GemfileLockExistsFact is true", func(t *testing.T) {
+		d, err := os.MkdirTemp("", "test-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(d)
+		if err := os.WriteFile(filepath.Join(d, "Gemfile.lock"), []byte{}, 0644); err != nil {
+			t.Fatal(err)
+		}
+		fact, err := GemfileLockExistsRule(d, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, fact, GemfileLockExistsFact)
+	})
+
+	t.Run("GemfileLockExistsFact is false", func(t *testing.T) {
+		d, err := os.MkdirTemp("", "test-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(d)
+		fact, err := GemfileLockExistsRule(d, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.NotEqual(t, fact, GemfileLockExistsFact)
+	})
+}
+
+func TestRspecExistsRule(t *testing.T) {
+	for _, name := range []string{".rspec", "spec/spec_helper.rb"} {
+		t.Run("RspecExistsFact is true with "+name, func(t *testing.T) {
+			d, err := os.MkdirTemp("", "test-src")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(d)
+			if err := os.MkdirAll(filepath.Dir(filepath.Join(d, filepath.FromSlash(name))), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(d, filepath.FromSlash(name)), []byte{}, 0644); err != nil {
+				t.Fatal(err)
+			}
+			fact, err := RspecExistsRule(d, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, fact, RspecExistsFact)
+		})
+	}
+
+	t.Run("RspecExistsFact is false", func(t *testing.T) {
+		d, err := os.MkdirTemp("", "test-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(d)
+		fact, err := RspecExistsRule(d, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.NotEqual(t, fact, RspecExistsFact)
+	})
+}
+
+func TestMinitestExistsRule(t *testing.T) {
+	t.Run("MinitestExistsFact is true", func(t *testing.T) {
+		d, err := os.MkdirTemp("", "test-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(d)
+		if err := os.MkdirAll(filepath.Join(d, "test"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(d, "test", "test_helper.rb"), []byte{}, 0644); err != nil {
+			t.Fatal(err)
+		}
+		fact, err := MinitestExistsRule(d, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, fact, MinitestExistsFact)
+	})
+
+	t.Run("MinitestExistsFact is false", func(t *testing.T) {
+		d, err := os.MkdirTemp("", "test-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(d)
+		fact, err := MinitestExistsRule(d, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.NotEqual(t, fact, MinitestExistsFact)
+	})
+}
+
+func TestRubocopConfigExistsRule(t *testing.T) {
+	t.Run("RubocopConfigExistsFact is true", func(t *testing.T) {
+		d, err := os.MkdirTemp("", "test-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(d)
+		if err := os.WriteFile(filepath.Join(d, ".rubocop.yml"), []byte{}, 0644); err != nil {
+			t.Fatal(err)
+		}
+		fact, err := RubocopConfigExistsRule(d, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, fact, RubocopConfigExistsFact)
+	})
+
+	t.Run("RubocopConfigExistsFact is false", func(t *testing.T) {
+		d, err := os.MkdirTemp("", "test-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(d)
+		fact, err := RubocopConfigExistsRule(d, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.NotEqual(t, fact, RubocopConfigExistsFact)
+	})
+}
@@ -0,0 +1,84 @@
+package ruby
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"dagger.io/dagger"
+	"github.com/trustacks/trustacks/pkg/engine"
+)
+
+// defaultVersion is the ruby version used when the source does not pin
+// the ruby version.
+const defaultVersion = "3.3"
+
+// gemfileRubyPattern matches the ruby directive of the Gemfile (ie.
+// ruby "3.2.2" or ruby '~> 3.2').
+var gemfileRubyPattern = regexp.MustCompile(`(?m)^\s*ruby\s+["']([^"']+)["']`)
+
+// versionPattern matches a ruby version of a requirement.
+var versionPattern = regexp.MustCompile(`\d+\.\d+(?:\.\d+)?`)
+
+// rubyImage returns the ruby image of the version.
+func rubyImage(version string) string {
+	return fmt.Sprintf("ruby:%s", version)
+}
+
+// requirementVersion returns the image version of the ruby requirement.
+// Exact versions are used as is, and the major.minor version of the
+// lower bound is used for ranges (ie. ~> 3.2.1 => 3.2).
+func requirementVersion(requirement string) string {
+	requirement = strings.TrimPrefix(strings.TrimSpace(requirement), "ruby-")
+	version := versionPattern.FindString(requirement)
+	if version == "" || version == requirement {
+		return version
+	}
+	parts := strings.Split(version, ".")
+	return parts[0] + "." + parts[1]
+}
+
+// sourceVersion returns the ruby version of the .ruby-version file or
+// the Gemfile ruby directive. The default version is returned if the
+// version is not pinned.
+func sourceVersion(entries []string, readFile engine.FileReader) (string, error) {
+	if engine.HasEntry(entries, ".ruby-version") {
+		data, err := readFile(".ruby-version")
+		if err != nil {
+			return "", err
+		}
+		if version := requirementVersion(string(data)); version != "" {
+			return version, nil
+		}
+	}
+	if engine.HasEntry(entries, "Gemfile") {
+		data, err := readFile("Gemfile")
+		if err != nil {
+			return "", err
+		}
+		if match := gemfileRubyPattern.FindSubmatch(data); match != nil {
+			if version := requirementVersion(string(match[1])); version != "" {
+				return version, nil
+			}
+		}
+	}
+	return defaultVersion, nil
+}
+
+// withRuby initializes the container from the ruby image of the source
+// ruby version.
+func withRuby(container *dagger.Container) (*dagger.Container, error) {
+	entries, err := container.Directory("/src").Entries(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	version, err := sourceVersion(entries, engine.ContainerFileReader(container))
+	if err != nil {
+		return nil, err
+	}
+	if version != defaultVersion {
+		container = engine.WithImage(container, fmt.Sprintf("ruby-%s", version), rubyImage(version))
+	}
+	return container, nil
+}
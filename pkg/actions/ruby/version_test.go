@@ -0,0 +1,47 @@
+package ruby
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequirementVersion(t *testing.T) {
+	assert.Equal(t, "3.2.2", requirementVersion("3.2.2\n"))
+	assert.Equal(t, "3.2.2", requirementVersion("ruby-3.2.2"))
+	assert.Equal(t, "3.2", requirementVersion("~> 3.2.1"))
+	assert.Equal(t, "3.1", requirementVersion(">= 3.1"))
+	assert.Equal(t, "", requirementVersion("system"))
+}
+
+func TestSourceVersion(t *testing.T) {
+	cases := []struct {
+		name    string
+		files   map[string]string
+		version string
+	}{
+		{"ruby version file", map[string]string{".ruby-version": "3.2.2\n", "Gemfile": `ruby "3.1.4"`}, "3.2.2"},
+		{"gemfile directive", map[string]string{"Gemfile": "source \"https://rubygems.org\"\n\nruby '~> 3.1.0'\n"}, "3.1"},
+		{"unpinned", map[string]string{"Gemfile": `source "https://rubygems.org"`}, defaultVersion},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			entries := []string{}
+			for name := range c.files {
+				entries = append(entries, name)
+			}
+			version, err := sourceVersion(entries, func(name string) ([]byte, error) {
+				contents, ok := c.files[name]
+				if !ok {
+					return nil, os.ErrNotExist
+				}
+				return []byte(contents), nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, c.version, version)
+		})
+	}
+}
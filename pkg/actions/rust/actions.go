@@ -0,0 +1,113 @@
+package rust
+
+import (
+	"context"
+	"path"
+	"strings"
+
+	"dagger.io/dagger"
+	"github.com/trustacks/trustacks/pkg/engine"
+)
+
+// caches are the cargo registry, git and target caches.
+var caches = []string{
+	"/usr/local/cargo/registry",
+	"/usr/local/cargo/git",
+	"/src/target",
+}
+
+// sourceCargoArgs returns the cargo command of the Cargo.lock and
+// workspace facts of the source.
+func sourceCargoArgs(utils *engine.ActionUtilities, command string, args ...string) []string {
+	return cargoArgs(utils.HasFact(CargoLockExistsFact), utils.HasFact(CargoWorkspaceExistsFact), command, args...)
+}
+
+var cargoBuild = &engine.Action{
+	Name:        "cargoBuild",
+	DisplayName: "Cargo Build",
+	Description: "Build the rust application binaries with cargo.",
+	Image:       func(_ *engine.Config) string { return "rust" },
+	Stage:       engine.OnDemand,
+	Caches:      caches,
+	OutputArtifacts: []engine.Artifact{
+		engine.BuildArtifact,
+	},
+	Script: func(container *dagger.Container, _ map[string]interface{}, utils *engine.ActionUtilities) error {
+		build := strings.Join(sourceCargoArgs(utils, "build", "--release", "--message-format", "json-render-diagnostics"), " ")
+		container = container.WithExec([]string{"/bin/sh", "-c", build + " > /tmp/cargo-build.json"})
+		messages, err := container.File("/tmp/cargo-build.json").Contents(context.Background())
+		if err != nil {
+			return err
+		}
+		executables, err := buildExecutables(strings.NewReader(messages))
+		if err != nil {
+			return err
+		}
+		// the target directory is a cache, so the binaries are copied
+		// to the build output.
+		container = container.WithExec([]string{"mkdir", "-p", "/tmp/.build"})
+		for _, executable := range executables {
+			container = container.WithExec([]string{"cp", executable, path.Join("/tmp/.build", path.Base(executable))})
+		}
+		if err := utils.Export(container, engine.BuildArtifact, "/tmp/.build"); err != nil {
+			return err
+		}
+		_, err = container.Sync(context.Background())
+		return err
+	},
+	AdmissionCriteria: []engine.Fact{CargoBinaryExistsFact},
+}
+
+var cargoTest = &engine.Action{
+	Name:        "cargoTest",
+	DisplayName: "Cargo Test",
+	Description: "Run the rust test suite with cargo test.",
+	Image:       func(_ *engine.Config) string { return "rust" },
+	Stage:       engine.CommitStage,
+	Caches:      caches,
+	Script: func(container *dagger.Container, _ map[string]interface{}, utils *engine.ActionUtilities) error {
+		_, err := container.WithExec(sourceCargoArgs(utils, "test")).Sync(context.Background())
+		return err
+	},
+	AdmissionCriteria: []engine.Fact{CargoTomlExistsFact},
+}
+
+var cargoClippy = &engine.Action{
+	Name:        "cargoClippy",
+	DisplayName: "Cargo Clippy",
+	Description: "Lint the rust source with clippy.",
+	Image:       func(_ *engine.Config) string { return "rust" },
+	Stage:       engine.CommitStage,
+	Caches:      caches,
+	Script: func(container *dagger.Container, _ map[string]interface{}, utils *engine.ActionUtilities) error {
+		_, err := container.
+			WithExec([]string{"rustup", "component", "add", "clippy"}).
+			WithExec(sourceCargoArgs(utils, "clippy", "--all-targets", "--", "-D", "warnings")).
+			Sync(context.Background())
+		return err
+	},
+	AdmissionCriteria: []engine.Fact{CargoTomlExistsFact},
+}
+
+var cargoFmt = &engine.Action{
+	Name:        "cargoFmt",
+	DisplayName: "Cargo Fmt",
+	Description: "Check the rust source formatting with rustfmt.",
+	Image:       func(_ *engine.Config) string { return "rust" },
+	Stage:       engine.CommitStage,
+	Script: func(container *dagger.Container, _ map[string]interface{}, _ *engine.ActionUtilities) error {
+		_, err := container.
+			WithExec([]string{"rustup", "component", "add", "rustfmt"}).
+			WithExec([]string{"cargo", "fmt", "--all", "--check"}).
+			Sync(context.Background())
+		return err
+	},
+	AdmissionCriteria: []engine.Fact{CargoTomlExistsFact},
+}
+
+func init() {
+	engine.RegisterAction(cargoBuild)
+	engine.RegisterAction(cargoTest)
+	engine.RegisterAction(cargoClippy)
+	engine.RegisterAction(cargoFmt)
+}
@@ -0,0 +1,156 @@
+package rust
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+// cargoManifest is the subset of the Cargo.toml that describes the
+// package binaries and the workspace members.
+type cargoManifest struct {
+	Package *struct {
+		Name string `toml:"name"`
+	} `toml:"package"`
+	Bins []struct {
+		Name string `toml:"name"`
+	} `toml:"bin"`
+	Workspace *struct {
+		Members []string `toml:"members"`
+		Exclude []string `toml:"exclude"`
+	} `toml:"workspace"`
+}
+
+// readManifest reads the Cargo.toml in the directory.
+func readManifest(dir string) (cargoManifest, error) {
+	var manifest cargoManifest
+	data, err := os.ReadFile(filepath.Join(dir, "Cargo.toml"))
+	if err != nil {
+		return manifest, err
+	}
+	if err := toml.Unmarshal(data, &manifest); err != nil {
+		return manifest, err
+	}
+	return manifest, nil
+}
+
+// workspaceMembers returns the package directories of the workspace
+// relative to the source root. The member globs are expanded and the
+// excluded members are removed.
+func workspaceMembers(source string, manifest cargoManifest) ([]string, error) {
+	if manifest.Workspace == nil {
+		return nil, nil
+	}
+	excluded := map[string]bool{}
+	for _, path := range manifest.Workspace.Exclude {
+		excluded[filepath.Clean(path)] = true
+	}
+	members := []string{}
+	for _, pattern := range manifest.Workspace.Members {
+		matches, err := filepath.Glob(filepath.Join(source, pattern))
+		if err != nil {
+			return nil, err
+		}
+		for _, match := range matches {
+			member, err := filepath.Rel(source, match)
+			if err != nil {
+				return nil, err
+			}
+			if excluded[member] {
+				continue
+			}
+			if _, err := os.Stat(filepath.Join(match, "Cargo.toml")); err != nil {
+				continue
+			}
+			members = append(members, member)
+		}
+	}
+	return members, nil
+}
+
+// packageHasBinary returns true if the package in the directory has a
+// src/main.rs, src/bin directory or [[bin]] target.
+func packageHasBinary(dir string, manifest cargoManifest) bool {
+	if manifest.Package == nil {
+		return false
+	}
+	if len(manifest.Bins) > 0 {
+		return true
+	}
+	for _, path := range []string{filepath.Join("src", "main.rs"), filepath.Join("src", "bin")} {
+		if _, err := os.Stat(filepath.Join(dir, path)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// sourceHasBinary returns true if the root package or any of the
+// workspace members has a binary target.
+func sourceHasBinary(source string) (bool, error) {
+	manifest, err := readManifest(source)
+	if err != nil {
+		return false, err
+	}
+	if packageHasBinary(source, manifest) {
+		return true, nil
+	}
+	members, err := workspaceMembers(source, manifest)
+	if err != nil {
+		return false, err
+	}
+	for _, member := range members {
+		memberManifest, err := readManifest(filepath.Join(source, member))
+		if err != nil {
+			return false, err
+		}
+		if packageHasBinary(filepath.Join(source, member), memberManifest) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// cargoArgs returns the cargo command of the source. The dependencies
+// are not updated if the Cargo.lock exists, and workspace commands run
+// for every workspace member.
+func cargoArgs(locked, workspace bool, command string, args ...string) []string {
+	cargo := []string{"cargo", command}
+	if locked {
+		cargo = append(cargo, "--locked")
+	}
+	if workspace {
+		cargo = append(cargo, "--workspace")
+	}
+	return append(cargo, args...)
+}
+
+// buildExecutables returns the executables of the cargo build json
+// messages.
+func buildExecutables(r io.Reader) ([]string, error) {
+	executables := []string{}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024) //nolint:gomnd
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		message := struct {
+			Reason     string  `json:"reason"`
+			Executable *string `json:"executable"`
+		}{}
+		if err := json.Unmarshal(line, &message); err != nil {
+			return nil, err
+		}
+		if message.Reason == "compiler-artifact" && message.Executable != nil {
+			executables = append(executables, *message.Executable)
+		}
+	}
+	return executables, scanner.Err()
+}
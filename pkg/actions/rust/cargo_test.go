@@ -0,0 +1,25 @@
+package rust
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCargoArgs(t *testing.T) {
+	assert.Equal(t, []string{"cargo", "test", "--locked", "--workspace"}, cargoArgs(true, true, "test"))
+	assert.Equal(t, []string{"cargo", "test", "--workspace"}, cargoArgs(false, true, "test"))
+	assert.Equal(t, []string{"cargo", "clippy", "--locked", "--all-targets"}, cargoArgs(true, false, "clippy", "--all-targets"))
+}
+
+func TestBuildExecutables(t *testing.T) {
+	executables, err := buildExecutables(strings.NewReader(`{"reason":"compiler-artifact","target":{"name":"lib"},"executable":null}
+{"reason":"compiler-artifact","target":{"name":"cli"},"executable":"/src/target/release/cli"}
+{"reason":"build-finished","success":true}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"/src/target/release/cli"}, executables)
+}
@@ -0,0 +1,83 @@
+package rust
+
+import (
+	"os"
+	"path/filepath"
+
+	mapset "github.com/deckarep/golang-set/v2"
+	"github.com/trustacks/trustacks/pkg/engine"
+)
+
+var (
+	// CargoTomlExistsFact is true if the Cargo.toml exists in the root
+	// of the application source.
+	CargoTomlExistsFact = engine.NewFact()
+	// CargoLockExistsFact is true if the Cargo.lock exists in the root
+	// of the application source.
+	CargoLockExistsFact = engine.NewFact()
+	// CargoWorkspaceExistsFact is true if the Cargo.toml has a
+	// [workspace] table.
+	CargoWorkspaceExistsFact = engine.NewFact()
+	// CargoBinaryExistsFact is true if the root package or a workspace
+	// member has a binary target.
+	CargoBinaryExistsFact = engine.NewFact()
+)
+
+// CargoTomlExistsRule checks if the Cargo.toml exists in the root of the
+// source.
+var CargoTomlExistsRule engine.Rule = func(source string, _ engine.Collector, _ mapset.Set[engine.Fact]) (engine.Fact, error) {
+	var fact = engine.NilFact
+	if _, err := os.Stat(filepath.Join(source, "Cargo.toml")); os.IsNotExist(err) {
+		return fact, nil
+	} else if err != nil {
+		return fact, err
+	}
+	fact = CargoTomlExistsFact
+	return fact, nil
+}
+
+// CargoLockExistsRule checks if the Cargo.lock exists in the root of the
+// source.
+var CargoLockExistsRule engine.Rule = func(source string, _ engine.Collector, _ mapset.Set[engine.Fact]) (engine.Fact, error) {
+	var fact = engine.NilFact
+	if _, err := os.Stat(filepath.Join(source, "Cargo.lock")); os.IsNotExist(err) {
+		return fact, nil
+	} else if err != nil {
+		return fact, err
+	}
+	fact = CargoLockExistsFact
+	return fact, nil
+}
+
+// CargoWorkspaceExistsRule checks if the Cargo.toml is a workspace
+// manifest.
+var CargoWorkspaceExistsRule engine.Rule = func(source string, _ engine.Collector, _ mapset.Set[engine.Fact]) (engine.Fact, error) {
+	var fact = engine.NilFact
+	manifest, err := readManifest(source)
+	if err != nil {
+		return fact, err
+	}
+	if manifest.Workspace != nil {
+		fact = CargoWorkspaceExistsFact
+	}
+	return fact, nil
+}
+
+// CargoBinaryExistsRule checks if the source has a binary target.
+var CargoBinaryExistsRule engine.Rule = func(source string, _ engine.Collector, _ mapset.Set[engine.Fact]) (engine.Fact, error) {
+	var fact = engine.NilFact
+	ok, err := sourceHasBinary(source)
+	if err != nil {
+		return fact, err
+	}
+	if ok {
+		fact = CargoBinaryExistsFact
+	}
+	return fact, nil
+}
+
+func init() {
+	engine.AddToRuleset(&CargoTomlExistsRule, &CargoLockExistsRule)
+	engine.AddToRuleset(&CargoTomlExistsRule, &CargoWorkspaceExistsRule)
+	engine.AddToRuleset(&CargoTomlExistsRule, &CargoBinaryExistsRule)
+}
@@ -0,0 +1,207 @@
+package rust
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCargoTomlExistsRule(t *testing.T) {
+	t.Run("CargoTomlExistsFact is true", func(t *testing.T) {
+		d, err := os.MkdirTemp("", "test-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(d)
+		if err := os.WriteFile(filepath.Join(d, "Cargo.toml"), []byte("[package]\nname = \"app\"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		fact, err := CargoTomlExistsRule(d, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, fact, CargoTomlExistsFact)
+	})
+
+	t.Run("CargoTomlExistsFact is false", func(t *testing.T) {
+		d, err := os.MkdirTemp("", "test-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(d)
+		fact, err := CargoTomlExistsRule(d, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.NotEqual(t, fact, CargoTomlExistsFact)
+	})
+}
+
+func TestCargoLockExistsRule(t *testing.T) {
+	t.Run("CargoLockExistsFact is true", func(t *testing.T) {
+		d, err := os.MkdirTemp("", "test-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(d)
+		if err := os.WriteFile(filepath.Join(d, "Cargo.lock"), []byte("version = 3\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		fact, err := CargoLockExistsRule(d, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, fact, CargoLockExistsFact)
+	})
+
+	t.Run("CargoLockExistsFact is false", func(t *testing.T) {
+		d, err := os.MkdirTemp("", "test-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(d)
+		fact, err := CargoLockExistsRule(d, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.NotEqual(t, fact, CargoLockExistsFact)
+	})
+}
+
+func TestCargoWorkspaceExistsRule(t *testing.T) {
+	t.Run("CargoWorkspaceExistsFact is true", func(t *testing.T) {
+		d, err := os.MkdirTemp("", "test-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(d)
+		if err := os.WriteFile(filepath.Join(d, "Cargo.toml"), []byte("[workspace]\nmembers = [\"crates/*\"]\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		fact, err := CargoWorkspaceExistsRule(d, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, fact, CargoWorkspaceExistsFact)
+	})
+
+	t.Run("CargoWorkspaceExistsFact is false", func(t *testing.T) {
+		d, err := os.MkdirTemp("", "test-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(d)
+		if err := os.WriteFile(filepath.Join(d, "Cargo.toml"), []byte("[package]\nname = \"app\"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		fact, err := CargoWorkspaceExistsRule(d, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.NotEqual(t, fact, CargoWorkspaceExistsFact)
+	})
+}
+
+func TestCargoBinaryExistsRule(t *testing.T) {
+	t.Run("CargoBinaryExistsFact is true", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			manifest string
+			dirs     []string
+			files    []string
+		}{
+			{"main.rs", "[package]\nname = \"app\"\n", []string{"src"}, []string{"src/main.rs"}},
+			{"src/bin", "[package]\nname = \"app\"\n", []string{"src/bin"}, []string{"src/bin/tool.rs"}},
+			{"bin target", "[package]\nname = \"app\"\n\n[[bin]]\nname = \"tool\"\npath = \"tools/tool.rs\"\n", nil, nil},
+		}
+		for _, tc := range tests {
+			d, err := os.MkdirTemp("", "test-src")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(d)
+			if err := os.WriteFile(filepath.Join(d, "Cargo.toml"), []byte(tc.manifest), 0644); err != nil {
+				t.Fatal(err)
+			}
+			for _, dir := range tc.dirs {
+				if err := os.MkdirAll(filepath.Join(d, dir), 0755); err != nil {
+					t.Fatal(err)
+				}
+			}
+			for _, file := range tc.files {
+				if err := os.WriteFile(filepath.Join(d, file), []byte{}, 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			fact, err := CargoBinaryExistsRule(d, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, fact, CargoBinaryExistsFact, tc.name)
+		}
+	})
+
+	t.Run("CargoBinaryExistsFact is true for workspace members", func(t *testing.T) {
+		d, err := os.MkdirTemp("", "test-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(d)
+		if err := os.WriteFile(filepath.Join(d, "Cargo.toml"), []byte("[workspace]\nmembers = [\"crates/*\"]\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		for _, member := range []string{"lib", "cli"} {
+			if err := os.MkdirAll(filepath.Join(d, "crates", member, "src"), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(d, "crates", member, "Cargo.toml"), []byte("[package]\nname = \""+member+"\"\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := os.WriteFile(filepath.Join(d, "crates", "lib", "src", "lib.rs"), []byte{}, 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(d, "crates", "cli", "src", "main.rs"), []byte{}, 0644); err != nil {
+			t.Fatal(err)
+		}
+		fact, err := CargoBinaryExistsRule(d, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, fact, CargoBinaryExistsFact)
+
+		// the binary member is excluded from the workspace.
+		if err := os.WriteFile(filepath.Join(d, "Cargo.toml"), []byte("[workspace]\nmembers = [\"crates/*\"]\nexclude = [\"crates/cli\"]\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		fact, err = CargoBinaryExistsRule(d, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.NotEqual(t, fact, CargoBinaryExistsFact)
+	})
+
+	t.Run("CargoBinaryExistsFact is false", func(t *testing.T) {
+		d, err := os.MkdirTemp("", "test-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(d)
+		if err := os.MkdirAll(filepath.Join(d, "src"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(d, "Cargo.toml"), []byte("[package]\nname = \"lib\"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(d, "src", "lib.rs"), []byte{}, 0644); err != nil {
+			t.Fatal(err)
+		}
+		fact, err := CargoBinaryExistsRule(d, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.NotEqual(t, fact, CargoBinaryExistsFact)
+	})
+}
@@ -2,6 +2,7 @@ package tox
 
 import (
 	"context"
+	"errors"
 
 	"dagger.io/dagger"
 	"github.com/trustacks/trustacks/pkg/actions/python"
@@ -14,16 +15,43 @@ var toxRunAction = &engine.Action{
 	Description: "Run the python test suite using tox",
 	Image:       func(_ *engine.Config) string { return "python" },
 	Stage:       engine.CommitStage,
-	Script: func(container *dagger.Container, _ map[string]interface{}, _ *engine.ActionUtilities) error {
-		container, err := python.InstallPythonDependencies(container)
+	Caches:      python.Caches,
+	OutputArtifacts: []engine.Artifact{
+		engine.CoverageArtifact,
+	},
+	Script: func(container *dagger.Container, _ map[string]interface{}, utils *engine.ActionUtilities) error {
+		toxIni, err := container.File("/src/tox.ini").Contents(context.Background())
 		if err != nil {
 			return err
 		}
-		container = container.WithExec([]string{"pip", "install", "tox"})
-		container = container.WithExec([]string{"tox", "run"})
-		_, err = container.Sync(context.Background())
-		return err
-
+		envs, err := python.ToxEnvironments([]byte(toxIni))
+		if err != nil {
+			return err
+		}
+		ran := map[string]bool{}
+		err = python.RunMatrix(container, utils, func(container *dagger.Container, version string, primary bool) error {
+			versionEnvs := versionEnvironments(envs, version, primary)
+			if len(envs) > 0 && len(versionEnvs) == 0 {
+				return nil
+			}
+			container, _, err := python.InstallPythonDependencies(container)
+			if err != nil {
+				return err
+			}
+			container = container.WithExec([]string{"pip", "install", "tox"})
+			container = container.WithExec(runArgs(versionEnvs))
+			for _, env := range versionEnvs {
+				ran[env] = true
+			}
+			if !primary {
+				_, err := container.Sync(context.Background())
+				return err
+			}
+			// the coverage.xml is exported if the tox environments report
+			// coverage.
+			return python.ExportCoverage(container, utils)
+		})
+		return errors.Join(err, missingEnvironments(envs, ran))
 	},
 	AdmissionCriteria: []engine.Fact{ToxIniExistsFact},
 }
@@ -0,0 +1,48 @@
+package tox
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/trustacks/trustacks/pkg/actions/python"
+)
+
+// versionEnvironments returns the tox environments that run in the
+// container of the python version. Environments without a version
+// factor (ie. lint or docs) only run in the primary container.
+func versionEnvironments(envs []string, version string, primary bool) []string {
+	selected := []string{}
+	for _, env := range envs {
+		envVersion := python.ToxEnvironmentVersion(env)
+		if envVersion == version || (envVersion == "" && primary) {
+			selected = append(selected, env)
+		}
+	}
+	return selected
+}
+
+// missingEnvironments returns an error if an environment of the envlist
+// did not run in any container of the matrix.
+func missingEnvironments(envs []string, ran map[string]bool) error {
+	missing := []string{}
+	for _, env := range envs {
+		if !ran[env] {
+			missing = append(missing, env)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("the tox environments %s do not match a python version of the test matrix", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// runArgs returns the tox command that runs the environments. The
+// default environment is run if the envlist is empty. Missing
+// interpreters fail the run instead of being skipped.
+func runArgs(envs []string) []string {
+	args := []string{"tox", "run", "--skip-missing-interpreters", "false"}
+	if len(envs) > 0 {
+		args = append(args, "-e", strings.Join(envs, ","))
+	}
+	return args
+}
@@ -0,0 +1,26 @@
+package tox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersionEnvironments(t *testing.T) {
+	envs := []string{"py310-django", "py311-django", "lint", "py"}
+	assert.Equal(t, []string{"py310-django", "lint", "py"}, versionEnvironments(envs, "3.10", true))
+	assert.Equal(t, []string{"py311-django"}, versionEnvironments(envs, "3.11", false))
+	assert.Empty(t, versionEnvironments(envs, "3.12", false))
+}
+
+func TestMissingEnvironments(t *testing.T) {
+	envs := []string{"py38", "py311", "lint"}
+	assert.NoError(t, missingEnvironments(envs, map[string]bool{"py38": true, "py311": true, "lint": true}))
+	assert.ErrorContains(t, missingEnvironments(envs, map[string]bool{"py311": true, "lint": true}), "the tox environments py38 do not match")
+	assert.NoError(t, missingEnvironments(nil, map[string]bool{}))
+}
+
+func TestRunArgs(t *testing.T) {
+	assert.Equal(t, []string{"tox", "run", "--skip-missing-interpreters", "false", "-e", "py311,lint"}, runArgs([]string{"py311", "lint"}))
+	assert.Equal(t, []string{"tox", "run", "--skip-missing-interpreters", "false"}, runArgs(nil))
+}
@@ -18,13 +18,27 @@ var trivyImageAction = &engine.Action{
 		engine.ContainerImageArtifact,
 	},
 	Script: func(container *dagger.Container, _ map[string]interface{}, utils *engine.ActionUtilities) error {
-		container, imageMount, err := utils.MountImage(container, engine.ContainerImageArtifact)
-		if err != nil {
+		images := utils.Images(engine.ContainerImageArtifact)
+		if images == nil {
+			container, imageMount, err := utils.MountImage(container, engine.ContainerImageArtifact)
+			if err != nil {
+				return err
+			}
+			container = container.WithExec([]string{"image", "--input", imageMount.Path("image.tar")})
+			_, err = container.Sync(context.Background())
 			return err
 		}
-		container = container.WithExec([]string{"image", "--input", imageMount.Path("image.tar")})
-		_, err = container.Sync(context.Background())
-		return err
+		// scan each of the images built from the source.
+		for _, image := range images {
+			imageContainer, imageMount, err := utils.MountContainerImage(container, image.Variants[0], engine.ContainerImageArtifact)
+			if err != nil {
+				return err
+			}
+			if _, err := imageContainer.WithExec([]string{"image", "--input", imageMount.Path("image.tar")}).Sync(context.Background()); err != nil {
+				return err
+			}
+		}
+		return nil
 	},
 	AdmissionCriteria: []engine.Fact{TrivyConfigExistsFact},
 }
@@ -0,0 +1,74 @@
+package typescript
+
+import (
+	"context"
+
+	"dagger.io/dagger"
+	"github.com/trustacks/trustacks/pkg/actions/javascript"
+	"github.com/trustacks/trustacks/pkg/engine"
+)
+
+// tscArgs returns the tsc command of the package manager. npx and bunx
+// install the package of the binary name if it is not a dependency, so
+// the typescript package is named explicitly instead of the unrelated
+// tsc package.
+func tscArgs(manager string, args ...string) []string {
+	switch manager {
+	case javascript.Npm:
+		return append([]string{"npx", "-y", "-p", "typescript", "tsc"}, args...)
+	case javascript.Bun:
+		return append([]string{"bunx", "-p", "typescript", "tsc"}, args...)
+	}
+	return javascript.ExecArgs(manager, "tsc", args...)
+}
+
+var tscTypeCheckAction = &engine.Action{
+	Name:        "tscTypeCheck",
+	DisplayName: "TypeScript Type Check",
+	Description: "Type-check the source with tsc --noEmit.",
+	Image:       func(_ *engine.Config) string { return "node:alpine" },
+	Stage:       engine.CommitStage,
+	Caches:      javascript.Caches,
+	Script: func(container *dagger.Container, _ map[string]interface{}, _ *engine.ActionUtilities) error {
+		container = container.WithExec([]string{"apk", "add", "bash"})
+		container, manager, err := javascript.InstallDependencies(container)
+		if err != nil {
+			return err
+		}
+		_, err = container.
+			WithExec(tscArgs(manager, "--noEmit")).
+			Sync(context.Background())
+		return err
+	},
+	AdmissionCriteria: []engine.Fact{TSConfigExistsFact},
+	ExclusionCriteria: []engine.Fact{TSProjectReferencesExistFact},
+}
+
+var tscBuildCheckAction = &engine.Action{
+	Name:        "tscBuildCheck",
+	DisplayName: "TypeScript Build Check",
+	Description: "Type-check the referenced typescript projects with tsc --build.",
+	Image:       func(_ *engine.Config) string { return "node:alpine" },
+	Stage:       engine.CommitStage,
+	Caches:      javascript.Caches,
+	Script: func(container *dagger.Container, _ map[string]interface{}, _ *engine.ActionUtilities) error {
+		container = container.WithExec([]string{"apk", "add", "bash"})
+		container, manager, err := javascript.InstallDependencies(container)
+		if err != nil {
+			return err
+		}
+		// composite projects must emit their declarations for the
+		// referencing projects, so the references are built. the
+		// outputs are discarded with the action container.
+		_, err = container.
+			WithExec(tscArgs(manager, "--build")).
+			Sync(context.Background())
+		return err
+	},
+	AdmissionCriteria: []engine.Fact{TSProjectReferencesExistFact},
+}
+
+func init() {
+	engine.RegisterAction(tscTypeCheckAction)
+	engine.RegisterAction(tscBuildCheckAction)
+}
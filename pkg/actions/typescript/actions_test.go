@@ -0,0 +1,15 @@
+package typescript
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustacks/trustacks/pkg/actions/javascript"
+)
+
+func TestTscArgs(t *testing.T) {
+	assert.Equal(t, []string{"npx", "-y", "-p", "typescript", "tsc", "--noEmit"}, tscArgs(javascript.Npm, "--noEmit"))
+	assert.Equal(t, []string{"bunx", "-p", "typescript", "tsc", "--noEmit"}, tscArgs(javascript.Bun, "--noEmit"))
+	assert.Equal(t, []string{"yarn", "run", "tsc", "--build"}, tscArgs(javascript.Yarn, "--build"))
+	assert.Equal(t, []string{"pnpm", "exec", "tsc", "--build"}, tscArgs(javascript.Pnpm, "--build"))
+}
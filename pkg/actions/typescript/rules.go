@@ -0,0 +1,55 @@
+package typescript
+
+import (
+	"os"
+	"path/filepath"
+
+	mapset "github.com/deckarep/golang-set/v2"
+	"github.com/trustacks/trustacks/pkg/actions/javascript"
+	"github.com/trustacks/trustacks/pkg/engine"
+)
+
+var (
+	// TSConfigExistsFact is true if the tsconfig.json file exists in the
+	// root of the application source.
+	TSConfigExistsFact = engine.NewFact()
+	// TSProjectReferencesExistFact is true if the tsconfig.json
+	// references other typescript projects.
+	TSProjectReferencesExistFact = engine.NewFact()
+)
+
+// TSConfigExistsRule checks if the tsconfig.json file exists in the root
+// of the application source.
+var TSConfigExistsRule engine.Rule = func(source string, _ engine.Collector, _ mapset.Set[engine.Fact]) (engine.Fact, error) {
+	var fact = engine.NilFact
+	if _, err := os.Stat(filepath.Join(source, "tsconfig.json")); os.IsNotExist(err) {
+		return fact, nil
+	} else if err != nil {
+		return fact, err
+	}
+	fact = TSConfigExistsFact
+	return fact, nil
+}
+
+// TSProjectReferencesExistRule checks if the tsconfig.json has project
+// references.
+var TSProjectReferencesExistRule engine.Rule = func(source string, _ engine.Collector, _ mapset.Set[engine.Fact]) (engine.Fact, error) {
+	var fact = engine.NilFact
+	data, err := os.ReadFile(filepath.Join(source, "tsconfig.json"))
+	if err != nil {
+		return fact, err
+	}
+	config, err := parseTSConfig(data)
+	if err != nil {
+		return fact, err
+	}
+	if len(config.References) > 0 {
+		fact = TSProjectReferencesExistFact
+	}
+	return fact, nil
+}
+
+func init() {
+	engine.AddToRuleset(&javascript.PackageJSONExistsRule, &TSConfigExistsRule)
+	engine.AddToRuleset(&TSConfigExistsRule, &TSProjectReferencesExistRule)
+}
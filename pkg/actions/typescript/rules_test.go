@@ -0,0 +1,79 @@
+package typescript
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTSConfigExistsRule(t *testing.T) {
+	t.Run("TSConfigExistsFact is true", func(t *testing.T) {
+		d, err := os.MkdirTemp("", "test-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(d)
+		if err := os.WriteFile(filepath.Join(d, "tsconfig.json"), []byte(`{}`), 0744); err != nil {
+			t.Fatal(err)
+		}
+		fact, err := TSConfigExistsRule(d, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, fact, TSConfigExistsFact)
+	})
+
+	t.Run("TSConfigExistsFact is false", func(t *testing.T) {
+		d, err := os.MkdirTemp("", "test-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(d)
+		fact, err := TSConfigExistsRule(d, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.NotEqual(t, fact, TSConfigExistsFact)
+	})
+}
+
+func TestTSProjectReferencesExistRule(t *testing.T) {
+	t.Run("TSProjectReferencesExistFact is true", func(t *testing.T) {
+		d, err := os.MkdirTemp("", "test-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(d)
+		contents := []byte(`{
+  // solution style config
+  "files": [],
+  "references": [{ "path": "./tsconfig.app.json" }, { "path": "./packages/lib" },],
+}`)
+		if err := os.WriteFile(filepath.Join(d, "tsconfig.json"), contents, 0744); err != nil {
+			t.Fatal(err)
+		}
+		fact, err := TSProjectReferencesExistRule(d, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, fact, TSProjectReferencesExistFact)
+	})
+
+	t.Run("TSProjectReferencesExistFact is false", func(t *testing.T) {
+		d, err := os.MkdirTemp("", "test-src")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(d)
+		if err := os.WriteFile(filepath.Join(d, "tsconfig.json"), []byte(`{"compilerOptions": {"strict": true}}`), 0744); err != nil {
+			t.Fatal(err)
+		}
+		fact, err := TSProjectReferencesExistRule(d, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.NotEqual(t, fact, TSProjectReferencesExistFact)
+	})
+}
@@ -0,0 +1,67 @@
+package typescript
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+var trailingCommaPattern = regexp.MustCompile(`,(\s*[}\]])`)
+
+// stripJSONComments removes the comments and trailing commas that are
+// allowed in tsconfig files.
+func stripJSONComments(data []byte) []byte {
+	var out strings.Builder
+	inString, lineComment, blockComment := false, false, false
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		switch {
+		case lineComment:
+			if c == '\n' {
+				lineComment = false
+				out.WriteByte(c)
+			}
+		case blockComment:
+			if c == '*' && i+1 < len(data) && data[i+1] == '/' {
+				blockComment = false
+				i++
+			}
+		case inString:
+			out.WriteByte(c)
+			if c == '\\' && i+1 < len(data) {
+				i++
+				out.WriteByte(data[i])
+			} else if c == '"' {
+				inString = false
+			}
+		case c == '"':
+			inString = true
+			out.WriteByte(c)
+		case c == '/' && i+1 < len(data) && data[i+1] == '/':
+			lineComment = true
+			i++
+		case c == '/' && i+1 < len(data) && data[i+1] == '*':
+			blockComment = true
+			i++
+		default:
+			out.WriteByte(c)
+		}
+	}
+	return trailingCommaPattern.ReplaceAll([]byte(out.String()), []byte("$1"))
+}
+
+// tsconfig is the subset of the tsconfig.json that references other
+// typescript projects.
+type tsconfig struct {
+	References []struct {
+		Path string `json:"path"`
+	} `json:"references"`
+}
+
+func parseTSConfig(data []byte) (*tsconfig, error) {
+	config := &tsconfig{}
+	if err := json.Unmarshal(stripJSONComments(data), config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
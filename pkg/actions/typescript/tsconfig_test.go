@@ -0,0 +1,40 @@
+package typescript
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStripJSONComments(t *testing.T) {
+	data := []byte(`{
+  // line comment
+  "extends": "./base.json", /* block
+  comment */
+  "compilerOptions": { "baseUrl": "http://example.com/*", },
+}`)
+	_, err := parseTSConfig(data)
+	assert.NoError(t, err)
+	stripped := string(stripJSONComments(data))
+	assert.NotContains(t, stripped, "comment")
+	assert.Contains(t, stripped, `"http://example.com/*"`)
+}
+
+func TestParseTSConfig(t *testing.T) {
+	config, err := parseTSConfig([]byte(`{"compilerOptions": {}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Empty(t, config.References)
+
+	config, err = parseTSConfig([]byte(`{
+  // solution style configuration
+  "files": [],
+  "references": [{"path": "./tsconfig.app.json"}, {"path": "packages/lib"},],
+}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, config.References, 2)
+	assert.Equal(t, "packages/lib", config.References[1].Path)
+}
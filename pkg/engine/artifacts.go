@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"dagger.io/dagger"
 	"github.com/lithammer/shortuuid"
@@ -16,6 +17,11 @@ const (
 	SemanticVersionArtifact
 	ContainerImageArtifact
 	CoverageArtifact
+	ImageDigestArtifact
+	ReleaseArtifact
+	VulnerabilityReportArtifact
+	CoverageReportArtifact
+	LintReportArtifact
 )
 
 type Artifact int
@@ -23,6 +29,7 @@ type Artifact int
 type ArtifactStore struct {
 	client    *dagger.Client
 	artifacts map[Artifact]*dagger.Container
+	images    map[Artifact][]Image
 	mounts    []*ArtifactMount
 }
 
@@ -47,15 +54,7 @@ func (as *ArtifactStore) MountImage(container *dagger.Container, artifact Artifa
 	if _, ok := as.artifacts[artifact]; !ok {
 		return container, nil, ErrArtifactNotFound
 	}
-	mount, err := newArtifactMount(artifact)
-	if err != nil {
-		return container, nil, err
-	}
-	as.mounts = append(as.mounts, mount)
-	if _, err = as.artifacts[artifact].Export(context.Background(), filepath.Join(mount.hostDir, "image.tar")); err != nil {
-		return container, nil, err
-	}
-	return container.WithDirectory(mount.path, as.client.Host().Directory(mount.hostDir)), mount, nil
+	return as.MountContainerImage(container, as.artifacts[artifact], artifact)
 }
 
 func (as *ArtifactStore) Export(container *dagger.Container, artifact Artifact, path string) error {
@@ -76,6 +75,46 @@ func (as *ArtifactStore) ExportContainer(container *dagger.Container, artifact A
 	return nil
 }
 
+// Image is a named container image with a container for each of its
+// target platforms.
+type Image struct {
+	Name     string
+	Variants []*dagger.Container
+}
+
+// ExportImages stores the images built from the source. The first
+// variant of the first image is used when the artifact is mounted as
+// an image.
+func (as *ArtifactStore) ExportImages(images []Image, artifact Artifact) error {
+	if len(images) == 0 || len(images[0].Variants) == 0 {
+		return fmt.Errorf("artifact with id '%d' has no images", artifact)
+	}
+	if err := as.ExportContainer(images[0].Variants[0], artifact); err != nil {
+		return err
+	}
+	as.images[artifact] = images
+	return nil
+}
+
+// Images returns the images stored in the artifact, or nil if the
+// artifact is a single container.
+func (as *ArtifactStore) Images(artifact Artifact) []Image {
+	return as.images[artifact]
+}
+
+// MountContainerImage mounts the container as an image tar.
+func (as *ArtifactStore) MountContainerImage(container *dagger.Container, image *dagger.Container, artifact Artifact) (*dagger.Container, *ArtifactMount, error) {
+	mount, err := newArtifactMount(artifact)
+	if err != nil {
+		return container, nil, err
+	}
+	as.mounts = append(as.mounts, mount)
+	if _, err = image.Export(context.Background(), filepath.Join(mount.hostDir, "image.tar")); err != nil {
+		return container, nil, err
+	}
+	return container.WithDirectory(mount.path, as.client.Host().Directory(mount.hostDir)), mount, nil
+}
+
 func (as *ArtifactStore) artifactPath(artifact Artifact) string {
 	return fmt.Sprintf("/tmp/_artifacts/%d", artifact)
 }
@@ -84,6 +123,7 @@ func newArtifactStore(client *dagger.Client) *ArtifactStore {
 	return &ArtifactStore{
 		client:    client,
 		artifacts: make(map[Artifact]*dagger.Container),
+		images:    make(map[Artifact][]Image),
 	}
 }
 
@@ -103,6 +143,12 @@ func (m *ArtifactMount) Close() {
 	defer os.RemoveAll(m.hostDir)
 }
 
+// PlatformPath returns the build artifact sub directory that contains
+// the build outputs for the platform (ie. linux/arm64 -> linux_arm64).
+func PlatformPath(platform string) string {
+	return strings.ReplaceAll(platform, "/", "_")
+}
+
 func newArtifactMount(artifact Artifact) (*ArtifactMount, error) {
 	d, err := os.MkdirTemp("", fmt.Sprintf("%d-artifact", artifact))
 	if err != nil {
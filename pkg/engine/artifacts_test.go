@@ -116,3 +116,22 @@ func TestArtifactPath(t *testing.T) {
 	store := &ArtifactStore{}
 	assert.Equal(t, "/tmp/_artifacts/23", store.artifactPath(mockArtifact))
 }
+
+func TestExportImages(t *testing.T) {
+	var mockImageArtifact Artifact = 23
+	store := newArtifactStore(nil)
+	assert.Error(t, store.ExportImages(nil, mockImageArtifact))
+	images := []Image{
+		{Name: "api", Variants: []*dagger.Container{{}, {}}},
+		{Name: "worker", Variants: []*dagger.Container{{}}},
+	}
+	if err := store.ExportImages(images, mockImageArtifact); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, images[0].Variants[0], store.artifacts[mockImageArtifact])
+	assert.Equal(t, images, store.Images(mockImageArtifact))
+}
+
+func TestPlatformPath(t *testing.T) {
+	assert.Equal(t, "linux_arm64", PlatformPath("linux/arm64"))
+}
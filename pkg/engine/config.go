@@ -2,6 +2,7 @@ package engine
 
 import (
 	"os"
+	"path/filepath"
 
 	"github.com/pelletier/go-toml"
 )
@@ -15,14 +16,64 @@ type ConfigCommon struct {
 }
 
 type ConfigPython struct {
-	Version         string   `toml:"version"`
-	Libraries       []string `toml:"libs"`
-	DevRequirements string   `toml:"dev_reqs"`
+	Version           string   `toml:"version"`
+	Versions          []string `toml:"versions"`
+	Libraries         []string `toml:"libs"`
+	DevRequirements   string   `toml:"dev_reqs"`
+	IndexURL          string   `toml:"index_url"`
+	CoverageThreshold float64  `toml:"coverage_threshold"`
 }
 
-type ConfigGolang struct {
+type ConfigJava struct {
 	Version string `toml:"version"`
-	LDFlags string `toml:"ldflags"`
+}
+
+type ConfigGolang struct {
+	Version           string               `toml:"version"`
+	LDFlags           string               `toml:"ldflags"`
+	Targets           []ConfigGolangTarget `toml:"targets"`
+	CoverageThreshold float64              `toml:"coverage_threshold"`
+}
+
+// ConfigGolangTarget is a cross compilation target of the golang build
+// matrix.
+type ConfigGolangTarget struct {
+	GOOS   string   `toml:"goos"`
+	GOARCH string   `toml:"goarch"`
+	GOARM  string   `toml:"goarm"`
+	CGO    bool     `toml:"cgo"`
+	Tags   []string `toml:"tags"`
+}
+
+type ConfigContainer struct {
+	Tags       []string               `toml:"tags"`
+	MainBranch string                 `toml:"main_branch"`
+	Platforms  []string               `toml:"platforms"`
+	Images     []ConfigContainerImage `toml:"images"`
+}
+
+// ConfigContainerImage declares an image that is built from the
+// source. Paths are relative to the source root.
+type ConfigContainerImage struct {
+	Name       string            `toml:"name"`
+	Dockerfile string            `toml:"dockerfile"`
+	Context    string            `toml:"context"`
+	Target     string            `toml:"target"`
+	BuildArgs  map[string]string `toml:"build_args"`
+	Secrets    []string          `toml:"secrets"`
+	Repository string            `toml:"repository"`
+}
+
+// ConfigCompose configures the compose acceptance tests.
+type ConfigCompose struct {
+	File    string `toml:"file"`
+	Command string `toml:"command"`
+	Service string `toml:"service"`
+}
+
+// ConfigGovulncheck configures the govulncheck vulnerability gate.
+type ConfigGovulncheck struct {
+	FailOn string `toml:"fail_on"`
 }
 
 type ConfigArgoCD struct {
@@ -30,19 +81,51 @@ type ConfigArgoCD struct {
 	Insecure bool `toml:"insecure"`
 }
 
+// ConfigGate pauses the run before a stage until it is approved.
+type ConfigGate struct {
+	Method  string `toml:"method"`
+	Timeout string `toml:"timeout"`
+	Marker  string `toml:"marker"`
+	Address string `toml:"address"`
+}
+
+// ConfigStages defines the order of the run stages, the stage that
+// actions are placed in and the stages skipped on prerelease runs.
+type ConfigStages struct {
+	Order   []string          `toml:"order"`
+	Actions map[string]string `toml:"actions"`
+	Release []string          `toml:"release"`
+}
+
 type Config struct {
-	Common ConfigCommon `toml:"common"`
-	Python ConfigPython `toml:"python"`
-	Golang ConfigGolang `toml:"golang"`
-	ArgoCD ConfigArgoCD `toml:"argocd"`
+	Common      ConfigCommon          `toml:"common"`
+	Python      ConfigPython          `toml:"python"`
+	Golang      ConfigGolang          `toml:"golang"`
+	Java        ConfigJava            `toml:"java"`
+	Container   ConfigContainer       `toml:"container"`
+	Govulncheck ConfigGovulncheck     `toml:"govulncheck"`
+	Compose     ConfigCompose         `toml:"compose"`
+	ArgoCD      ConfigArgoCD          `toml:"argocd"`
+	Stages      ConfigStages          `toml:"stages"`
+	Gates       map[string]ConfigGate `toml:"gates"`
 }
 
 func NewConfig() (*Config, error) {
+	return loadConfig(configPath)
+}
+
+// NewSourceConfig loads the declarative configuration from the root of
+// the application source.
+func NewSourceConfig(source string) (*Config, error) {
+	return loadConfig(filepath.Join(source, configPath))
+}
+
+func loadConfig(path string) (*Config, error) {
 	var config Config
-	if _, err := os.Stat(configPath); os.IsNotExist(err) {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
 		return &Config{}, nil
 	}
-	data, err := os.ReadFile(configPath)
+	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, err
 	}
@@ -2,6 +2,7 @@ package engine
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/pelletier/go-toml"
@@ -28,3 +29,31 @@ func TestNewConfig(t *testing.T) {
 	}
 	assert.Equal(t, "1.1.1", conf.Common.Version)
 }
+
+func TestNewSourceConfig(t *testing.T) {
+	d, err := os.MkdirTemp("", "test-src")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(d)
+	data := `
+[[container.images]]
+name = "api"
+dockerfile = "build/api.Dockerfile"
+target = "prod"
+build_args = { GOFLAGS = "-mod=vendor" }
+secrets = ["NPM_TOKEN"]`
+	if err := os.WriteFile(filepath.Join(d, "trustacks.toml"), []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	conf, err := NewSourceConfig(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, conf.Container.Images, 1)
+	assert.Equal(t, "api", conf.Container.Images[0].Name)
+	assert.Equal(t, "build/api.Dockerfile", conf.Container.Images[0].Dockerfile)
+	assert.Equal(t, "prod", conf.Container.Images[0].Target)
+	assert.Equal(t, map[string]string{"GOFLAGS": "-mod=vendor"}, conf.Container.Images[0].BuildArgs)
+	assert.Equal(t, []string{"NPM_TOKEN"}, conf.Container.Images[0].Secrets)
+}
@@ -1,5 +1,7 @@
 package engine
 
+import "sort"
+
 type Engine struct {
 	sourceCollector *SourceCollector
 }
@@ -13,6 +15,10 @@ func (engine *Engine) CreateActionPlan(source string) (*ActionPlan, error) {
 	if err != nil {
 		return nil, err
 	}
+	for _, fact := range facts.ToSlice() {
+		actionPlan.AddFact(fact)
+	}
+	sort.Slice(actionPlan.Facts, func(i, j int) bool { return actionPlan.Facts[i] < actionPlan.Facts[j] })
 	for _, action := range registeredActions {
 		pass := true
 		for _, fact := range action.AdmissionCriteria {
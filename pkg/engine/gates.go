@@ -0,0 +1,289 @@
+package engine
+
+import (
+	"bufio"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	PromptGate = "prompt"
+	MarkerGate = "marker"
+	HTTPGate   = "http"
+)
+
+const (
+	approvalAuditPath      = "./trustacks.approvals"
+	defaultGateHTTPAddress = ":8080"
+	markerPollInterval     = time.Second
+)
+
+var ErrApprovalTimeout = errors.New("timed out waiting for approval")
+
+// approval is the audit record of a gate decision.
+type approval struct {
+	Stage    string    `json:"stage"`
+	Method   string    `json:"method"`
+	Approver string    `json:"approver,omitempty"`
+	Approved bool      `json:"approved"`
+	Error    string    `json:"error,omitempty"`
+	Time     time.Time `json:"time"`
+}
+
+// approver blocks until a decision is made for the stage or the
+// context is done.
+type approver interface {
+	wait(ctx context.Context, stage string) (approval, error)
+}
+
+// promptApprover asks for approval on the terminal. A single reader
+// goroutine owns the input for the lifetime of the approver, so that
+// buffered input is not lost between gates and timed out prompts do not
+// leak readers.
+type promptApprover struct {
+	out   io.Writer
+	lines <-chan string
+}
+
+// stdinPrompt is the prompt approver shared by the gates of a run.
+var (
+	stdinPrompt     *promptApprover
+	stdinPromptOnce sync.Once
+)
+
+func newPromptApprover(in io.Reader, out io.Writer) *promptApprover {
+	lines := make(chan string)
+	go func() {
+		defer close(lines)
+		reader := bufio.NewReader(in)
+		for {
+			line, err := reader.ReadString('\n')
+			if line != "" {
+				lines <- line
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+	return &promptApprover{out: out, lines: lines}
+}
+
+func (a *promptApprover) wait(ctx context.Context, stage string) (approval, error) {
+	fmt.Fprintf(a.out, "Approve the '%s' stage? [y/N]: ", stage)
+	select {
+	case answer := <-a.lines:
+		answer = strings.ToLower(strings.TrimSpace(answer))
+		return approval{Approver: currentUser(), Approved: answer == "y" || answer == "yes"}, nil
+	case <-ctx.Done():
+		return approval{}, ctx.Err()
+	}
+}
+
+// markerApprover waits for a marker file to be created. The file
+// contents are recorded as the approver and the file is removed once
+// it has been consumed.
+type markerApprover struct {
+	path string
+}
+
+func (a *markerApprover) wait(ctx context.Context, _ string) (approval, error) {
+	ticker := time.NewTicker(markerPollInterval)
+	defer ticker.Stop()
+	for {
+		contents, err := os.ReadFile(a.path)
+		if err == nil {
+			if err := os.Remove(a.path); err != nil {
+				return approval{}, err
+			}
+			approver := strings.TrimSpace(string(contents))
+			if approver == "" {
+				approver = currentUser()
+			}
+			return approval{Approver: approver, Approved: true}, nil
+		} else if !os.IsNotExist(err) {
+			return approval{}, err
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return approval{}, ctx.Err()
+		}
+	}
+}
+
+// httpApprover serves an approval endpoint for runs executing as a
+// service. Requests must authenticate with the GATE_TOKEN input as a
+// bearer token.
+type httpApprover struct {
+	address string
+	token   string
+}
+
+type httpApprovalRequest struct {
+	Approver string `json:"approver"`
+	Approved bool   `json:"approved"`
+}
+
+// handler accepts a single decision for the stage at
+// POST /stages/<stage>/approval.
+func (a *httpApprover) handler(stage string, decision chan<- approval) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(fmt.Sprintf("/stages/%s/approval", stage), func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if a.token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(a.token)) != 1 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var req httpApprovalRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if req.Approver == "" {
+			http.Error(w, "approver is required", http.StatusBadRequest)
+			return
+		}
+		select {
+		case decision <- approval{Approver: req.Approver, Approved: req.Approved}:
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			w.WriteHeader(http.StatusConflict)
+		}
+	})
+	return mux
+}
+
+func (a *httpApprover) wait(ctx context.Context, stage string) (approval, error) {
+	decision := make(chan approval, 1)
+	server := &http.Server{Addr: a.address, Handler: a.handler(stage, decision), ReadHeaderTimeout: 10 * time.Second} //nolint:gomnd
+	serverErr := make(chan error, 1)
+	go func() {
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			serverErr <- err
+		}
+	}()
+	defer server.Close()
+	select {
+	case result := <-decision:
+		return result, nil
+	case err := <-serverErr:
+		return approval{}, err
+	case <-ctx.Done():
+		return approval{}, ctx.Err()
+	}
+}
+
+// newApprover creates the approver for the configured gate method.
+func newApprover(stage string, gate ConfigGate) (approver, error) {
+	switch gate.Method {
+	case PromptGate, "":
+		stdinPromptOnce.Do(func() {
+			stdinPrompt = newPromptApprover(os.Stdin, os.Stdout)
+		})
+		return stdinPrompt, nil
+	case MarkerGate:
+		path := gate.Marker
+		if path == "" {
+			path = fmt.Sprintf(".%s.approved", stage)
+		}
+		return &markerApprover{path: path}, nil
+	case HTTPGate:
+		address := gate.Address
+		if address == "" {
+			address = defaultGateHTTPAddress
+		}
+		token := os.Getenv(string(GateToken))
+		if token == "" {
+			return nil, fmt.Errorf("the http gate for stage '%s' requires the %s input", stage, GateToken)
+		}
+		return &httpApprover{address: address, token: token}, nil
+	default:
+		return nil, fmt.Errorf("unknown gate method '%s' for stage '%s'", gate.Method, stage)
+	}
+}
+
+// awaitApproval blocks until the stage gate is approved, denied or
+// timed out and records the outcome in the approval audit log.
+func awaitApproval(stage string, gate ConfigGate, auditPath string) error {
+	a, err := newApprover(stage, gate)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	if gate.Timeout != "" {
+		timeout, err := time.ParseDuration(gate.Timeout)
+		if err != nil {
+			return fmt.Errorf("invalid gate timeout for stage '%s': %s", stage, err)
+		}
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	method := gate.Method
+	if method == "" {
+		method = PromptGate
+	}
+	result, err := a.wait(ctx, stage)
+	result.Stage = stage
+	result.Method = method
+	result.Time = time.Now().UTC()
+	if errors.Is(err, context.DeadlineExceeded) {
+		err = ErrApprovalTimeout
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	if auditErr := writeApprovalAudit(auditPath, result); auditErr != nil {
+		return auditErr
+	}
+	if err != nil {
+		return fmt.Errorf("stage '%s' gate: %w", stage, err)
+	}
+	if !result.Approved {
+		return fmt.Errorf("stage '%s' was not approved by '%s'", stage, result.Approver)
+	}
+	return nil
+}
+
+// writeApprovalAudit appends the approval record to the audit log.
+func writeApprovalAudit(path string, record approval) error {
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil { //nolint:gomnd
+			return err
+		}
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644) //nolint:gomnd,gosec
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(append(data, '\n'))
+	return err
+}
+
+func currentUser() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	return os.Getenv("USER")
+}
@@ -0,0 +1,191 @@
+package engine
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pelletier/go-toml"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigGates(t *testing.T) {
+	var config Config
+	data := `
+[gates.deploy]
+method = "marker"
+timeout = "30m"
+marker = "approve"`
+	if err := toml.Unmarshal([]byte(data), &config); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "marker", config.Gates["deploy"].Method)
+	assert.Equal(t, "30m", config.Gates["deploy"].Timeout)
+	assert.Equal(t, "approve", config.Gates["deploy"].Marker)
+}
+
+func TestPromptApprover(t *testing.T) {
+	t.Run("approved", func(t *testing.T) {
+		a := newPromptApprover(strings.NewReader("y\n"), &bytes.Buffer{})
+		result, err := a.wait(context.Background(), "deploy")
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.True(t, result.Approved)
+	})
+	t.Run("denied", func(t *testing.T) {
+		a := newPromptApprover(strings.NewReader("\n"), &bytes.Buffer{})
+		result, err := a.wait(context.Background(), "deploy")
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.False(t, result.Approved)
+	})
+	t.Run("buffered answers are kept between gates", func(t *testing.T) {
+		a := newPromptApprover(strings.NewReader("y\nn\n"), &bytes.Buffer{})
+		deploy, err := a.wait(context.Background(), "deploy")
+		if err != nil {
+			t.Fatal(err)
+		}
+		release, err := a.wait(context.Background(), "release")
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.True(t, deploy.Approved)
+		assert.False(t, release.Approved)
+	})
+	t.Run("timeout", func(t *testing.T) {
+		in, w := io.Pipe()
+		defer w.Close()
+		a := newPromptApprover(in, &bytes.Buffer{})
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err := a.wait(ctx, "deploy")
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		// the answer is received by the next gate after the timeout.
+		go w.Write([]byte("y\n")) //nolint:errcheck
+		result, err := a.wait(context.Background(), "deploy")
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.True(t, result.Approved)
+	})
+}
+
+func TestMarkerApprover(t *testing.T) {
+	d, err := os.MkdirTemp("", "test-gates")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(d)
+	marker := filepath.Join(d, ".deploy.approved")
+	if err := os.WriteFile(marker, []byte("jdoe\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	result, err := (&markerApprover{path: marker}).wait(context.Background(), "deploy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, result.Approved)
+	assert.Equal(t, "jdoe", result.Approver)
+	assert.NoFileExists(t, marker)
+}
+
+func TestHTTPApproverHandler(t *testing.T) {
+	decision := make(chan approval, 1)
+	handler := (&httpApprover{token: "secret"}).handler("deploy", decision)
+	body, err := json.Marshal(httpApprovalRequest{Approver: "jdoe", Approved: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Run("unauthorized", func(t *testing.T) {
+		for _, header := range []string{"", "Bearer wrong"} {
+			req := httptest.NewRequest(http.MethodPost, "/stages/deploy/approval", bytes.NewReader(body))
+			if header != "" {
+				req.Header.Set("Authorization", header)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		}
+		assert.Empty(t, decision)
+	})
+	t.Run("approved", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/stages/deploy/approval", bytes.NewReader(body))
+		req.Header.Set("Authorization", "Bearer secret")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusAccepted, rec.Code)
+		result := <-decision
+		assert.True(t, result.Approved)
+		assert.Equal(t, "jdoe", result.Approver)
+	})
+}
+
+func TestAwaitApproval(t *testing.T) {
+	d, err := os.MkdirTemp("", "test-gates")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(d)
+	auditPath := filepath.Join(d, "approvals")
+	t.Run("timeout", func(t *testing.T) {
+		gate := ConfigGate{Method: MarkerGate, Marker: filepath.Join(d, "missing"), Timeout: "10ms"}
+		err := awaitApproval("deploy", gate, auditPath)
+		assert.ErrorIs(t, err, ErrApprovalTimeout)
+	})
+	t.Run("approved", func(t *testing.T) {
+		marker := filepath.Join(d, "approved")
+		if err := os.WriteFile(marker, []byte("jdoe"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		gate := ConfigGate{Method: MarkerGate, Marker: marker, Timeout: time.Minute.String()}
+		assert.NoError(t, awaitApproval("release", gate, auditPath))
+	})
+	f, err := os.Open(auditPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	records := []approval{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var record approval
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, record)
+	}
+	assert.Len(t, records, 2)
+	assert.False(t, records[0].Approved)
+	assert.Equal(t, ErrApprovalTimeout.Error(), records[0].Error)
+	assert.True(t, records[1].Approved)
+	assert.Equal(t, "jdoe", records[1].Approver)
+	assert.Equal(t, "release", records[1].Stage)
+}
+
+func TestNewApproverHTTPToken(t *testing.T) {
+	t.Setenv(string(GateToken), "")
+	_, err := newApprover("deploy", ConfigGate{Method: HTTPGate})
+	assert.ErrorContains(t, err, "requires the GATE_TOKEN input")
+	t.Setenv(string(GateToken), "secret")
+	a, err := newApprover("deploy", ConfigGate{Method: HTTPGate})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "secret", a.(*httpApprover).token)
+}
+
+func TestNewApproverUnknownMethod(t *testing.T) {
+	_, err := newApprover("deploy", ConfigGate{Method: "carrier-pigeon"})
+	assert.ErrorContains(t, err, "unknown gate method 'carrier-pigeon'")
+}
@@ -8,6 +8,8 @@ var inputs = map[string]input{
 	"ARGOCD_SERVER":               ArgoCDServerInput{},
 	"ARGOCD_AUTH_TOKEN":           ArgoCDAuthTokenInput{},
 	"GITHUB_TOKEN":                GithubTokenInput{},
+	"PYPI_TOKEN":                  PypiTokenInput{},
+	"GATE_TOKEN":                  GateTokenInput{},
 }
 
 type InputField string
@@ -100,6 +102,28 @@ func (input GithubTokenInput) Schema() InputFieldSchema {
 	}
 }
 
+const PypiToken InputField = "PYPI_TOKEN" //nolint:gosec
+
+type PypiTokenInput struct{}
+
+func (input PypiTokenInput) Schema() InputFieldSchema {
+	return InputFieldSchema{
+		Type:        "String",
+		Description: "The python package index API token",
+	}
+}
+
+const GateToken InputField = "GATE_TOKEN" //nolint:gosec
+
+type GateTokenInput struct{}
+
+func (input GateTokenInput) Schema() InputFieldSchema {
+	return InputFieldSchema{
+		Type:        "String",
+		Description: "The bearer token of the http approval gate endpoint",
+	}
+}
+
 func GetInput(name string) input {
 	return inputs[name]
 }
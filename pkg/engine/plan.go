@@ -14,16 +14,24 @@ import (
 )
 
 type ActionPlan struct {
-	Actions   []string `json:"actions"`
-	vars      map[string]interface{}
-	id        string
-	artifacts *ArtifactStore
+	Actions []string `json:"actions"`
+	// Facts are the source facts gathered when the plan was created, so
+	// that actions can adapt to the source (ie. lock files) at run time.
+	Facts      []Fact `json:"facts,omitempty"`
+	vars       map[string]interface{}
+	id         string
+	artifacts  *ArtifactStore
+	prerelease bool
 }
 
 func (ap *ActionPlan) AddAction(name string) {
 	ap.Actions = append(ap.Actions, name)
 }
 
+func (ap *ActionPlan) AddFact(fact Fact) {
+	ap.Facts = append(ap.Facts, fact)
+}
+
 func (ap *ActionPlan) ToJSON() (string, error) {
 	data, err := json.Marshal(ap)
 	if err != nil {
@@ -37,12 +45,12 @@ func (ap *ActionPlan) prepare(spec string, client *dagger.Client) error {
 	return json.Unmarshal([]byte(spec), &ap)
 }
 
-func (ap *ActionPlan) stageActions(stages []string) []string {
+func (ap *ActionPlan) stageActions(stages []string, layout *stageLayout) []string {
 	actions := []string{}
 	for _, stage := range stages {
 		for _, name := range ap.Actions {
 			action := registeredActions[name]
-			if GetStage(action.Stage) == stage {
+			if layout.stageName(action) == stage {
 				actions = append(actions, action.Name)
 			}
 		}
@@ -95,8 +103,12 @@ func (ap *ActionPlan) runAction(source string, action *Action, client *dagger.Cl
 	for _, path := range action.Caches {
 		container = container.WithMountedCache(path, client.CacheVolume(ap.id+path))
 	}
-	err := action.Script(container, ap.vars, newActionUtilities(client, ap.artifacts, config))
-	return stopLogger(err)
+	utils := newActionUtilities(client, ap.artifacts, config, ap.prerelease, ap.Facts)
+	err := stopLogger(action.Script(container, ap.vars, utils))
+	for _, line := range utils.summary {
+		fmt.Println("  " + line)
+	}
+	return err
 }
 
 func (ap *ActionPlan) close() {
@@ -118,6 +130,7 @@ type RunArgs struct {
 	Client              *dagger.Client
 	Stages              []string
 	IgnoreMissingInputs bool
+	Prerelease          bool
 }
 
 func Run(args RunArgs) error {
@@ -126,21 +139,42 @@ func Run(args RunArgs) error {
 		return err
 	}
 	defer ap.close()
+	config, err := NewConfig()
+	if err != nil {
+		return err
+	}
+	layout, err := newStageLayout(config.Stages)
+	if err != nil {
+		return err
+	}
+	if err := layout.checkGates(config.Gates); err != nil {
+		return err
+	}
+	for _, stage := range args.Stages {
+		if layout.index(stage) <= 0 {
+			return fmt.Errorf("stage '%s' is not defined", stage)
+		}
+	}
+	ap.prerelease = args.Prerelease
+	runStages := args.Stages
+	if args.Prerelease {
+		runStages = layout.withoutReleaseStages(runStages)
+	}
 	// stage "" is a placeholder for on-demand actions.
-	stages := append([]string{""}, args.Stages...)
-	actions := ap.stageActions(stages)
+	stages := append([]string{""}, runStages...)
+	actions := ap.stageActions(stages, layout)
 	if !args.IgnoreMissingInputs {
 		if err := ap.checkInputs(actions); err != nil {
 			return err
 		}
 	}
-	schedule, err := newScheduler().schedule(actions)
+	schedule, err := newScheduler(layout).schedule(actions)
 	if err != nil {
 		return err
 	}
 	for actionStage, actions := range schedule {
 		for _, stageID := range stages {
-			if stageID == actionStages[actionStage] {
+			if stageID == layout.name(actionStage) {
 				for _, action := range actions {
 					if os.Getenv("DEBUG") != "" {
 						log.Info(fmt.Sprintf("> %s", action.Name))
@@ -149,15 +183,16 @@ func Run(args RunArgs) error {
 			}
 		}
 	}
-	config, err := NewConfig()
-	if err != nil {
-		return err
-	}
-	for stageID, key := range actionStages {
+	for stageID, key := range layout.names {
 		for _, name := range stages {
 			if key == name {
 				stage := Stage(stageID)
 				if actions, ok := schedule[stage]; ok {
+					if gate, ok := config.Gates[key]; ok {
+						if err := awaitApproval(key, gate, approvalAuditPath); err != nil {
+							return err
+						}
+					}
 					for _, action := range actions {
 						if err := ap.runAction(args.Source, action, args.Client, config); err != nil {
 							return err
@@ -12,6 +12,17 @@ func TestActionPlanAddAction(t *testing.T) {
 	assert.Equal(t, "test", ap.Actions[0])
 }
 
+func TestActionPlanAddFact(t *testing.T) {
+	ap := NewActionPlan()
+	ap.AddFact(Fact(1))
+	ap.AddFact(Fact(3))
+	spec, err := ap.ToJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, `{"actions":null,"facts":[1,3]}`, spec)
+}
+
 func TestCheckInputs(t *testing.T) {
 	var mockInput InputField = "TEST"
 	var previousRegisteredActions = registeredActions
@@ -46,8 +57,32 @@ func TestStageActions(t *testing.T) {
 	}
 	ap := NewActionPlan()
 	ap.Actions = []string{"actionA", "actionB", "actionC"}
-	actions := ap.stageActions([]string{actionStages[CommitStage]})
+	actions := ap.stageActions([]string{actionStages[CommitStage]}, builtinStageLayout())
 	assert.Contains(t, actions, "actionA")
 	assert.Contains(t, actions, "actionB")
 	assert.NotContains(t, actions, "actionC")
 }
+
+func TestStageActionsWithOverrides(t *testing.T) {
+	var previousRegisteredActions = registeredActions
+	defer func() {
+		registeredActions = previousRegisteredActions
+	}()
+	mockActionA := &Action{Name: "actionA", Stage: NonFunctionalStage}
+	mockActionB := &Action{Name: "actionB", Stage: DeployStage}
+	registeredActions = map[string]*Action{
+		"actionA": mockActionA,
+		"actionB": mockActionB,
+	}
+	layout, err := newStageLayout(ConfigStages{
+		Order:   []string{"commit", "security", "deploy"},
+		Actions: map[string]string{"actionA": "security"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ap := NewActionPlan()
+	ap.Actions = []string{"actionA", "actionB"}
+	assert.Equal(t, []string{"actionA"}, ap.stageActions([]string{"security"}, layout))
+	assert.Equal(t, []string{"actionB"}, ap.stageActions([]string{"deploy"}, layout))
+}
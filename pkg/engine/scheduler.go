@@ -8,30 +8,38 @@ import (
 )
 
 type scheduler struct {
+	layout         *stageLayout
 	requiredInputs map[Artifact]mapset.Set[Stage]
 	optionalInputs map[Artifact]mapset.Set[Stage]
 }
 
-func newScheduler() *scheduler {
+func newScheduler(layout *stageLayout) *scheduler {
 	return &scheduler{
+		layout:         layout,
 		requiredInputs: map[Artifact]mapset.Set[Stage]{},
 		optionalInputs: map[Artifact]mapset.Set[Stage]{},
 	}
 }
 
-func (s *scheduler) assignActivityStage(actions []string) map[Stage]mapset.Set[*Action] {
+// assignActivityStage groups the actions by their index in the stage
+// layout.
+func (s *scheduler) assignActivityStage(actions []string) (map[Stage]mapset.Set[*Action], error) {
 	assignments := map[Stage]mapset.Set[*Action]{}
 	for _, actionName := range actions {
 		for registeredActionName, registeredAction := range registeredActions {
 			if actionName == registeredActionName {
-				if _, ok := assignments[registeredAction.Stage]; !ok {
-					assignments[registeredAction.Stage] = mapset.NewSet[*Action]()
+				stage, err := s.layout.stageOf(registeredAction)
+				if err != nil {
+					return nil, err
 				}
-				assignments[registeredAction.Stage].Add(registeredAction)
+				if _, ok := assignments[stage]; !ok {
+					assignments[stage] = mapset.NewSet[*Action]()
+				}
+				assignments[stage].Add(registeredAction)
 			}
 		}
 	}
-	return assignments
+	return assignments, nil
 }
 
 func (s *scheduler) bindActionInputs(assignments map[Stage]mapset.Set[*Action]) {
@@ -106,7 +114,7 @@ func (s *scheduler) assignOnDemandActions(assignments map[Stage]mapset.Set[*Acti
 func (s *scheduler) sortActions(assignments map[Stage]mapset.Set[*Action]) (map[Stage][]*Action, error) {
 	sortedAssignments := map[Stage][]*Action{}
 	assignedActionOutputs := []Artifact{}
-	for stageIndex := range actionStages {
+	for stageIndex := range s.layout.names {
 		stage := Stage(stageIndex)
 		if actions, ok := assignments[stage]; ok {
 			actionsWithUnresolvedInputs := mapset.NewSet[string]()
@@ -174,7 +182,10 @@ func (s *scheduler) sortActions(assignments map[Stage]mapset.Set[*Action]) (map[
 }
 
 func (s *scheduler) schedule(actions []string) (map[Stage][]*Action, error) {
-	assignments := s.assignActivityStage(actions)
+	assignments, err := s.assignActivityStage(actions)
+	if err != nil {
+		return nil, err
+	}
 	s.bindActionInputs(assignments)
 	if err := s.assignOnDemandActions(assignments); err != nil {
 		return nil, err
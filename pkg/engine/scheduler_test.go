@@ -20,8 +20,11 @@ func TestSchedulerAssignActivityStage(t *testing.T) {
 		"actionB": actionB,
 		"actionC": actionC,
 	}
-	s := newScheduler()
-	assignments := s.assignActivityStage([]string{"actionA", "actionB", "actionC"})
+	s := newScheduler(builtinStageLayout())
+	assignments, err := s.assignActivityStage([]string{"actionA", "actionB", "actionC"})
+	if err != nil {
+		t.Fatal(err)
+	}
 	assert.True(t, assignments[OnDemand].Contains(actionA))
 	assert.True(t, assignments[CommitStage].Contains(actionB))
 	assert.True(t, assignments[DeployStage].Contains(actionC))
@@ -37,7 +40,7 @@ func TestBindActionInputs(t *testing.T) {
 	assignments[OnDemand].Add(&Action{Name: "actionA", Stage: OnDemand, OutputArtifacts: []Artifact{mockArtifact}})
 	assignments[CommitStage].Add(&Action{Name: "actionB", Stage: CommitStage})
 	assignments[DeployStage].Add(&Action{Name: "actionC", Stage: DeployStage, InputArtifacts: []Artifact{mockArtifact}})
-	s := newScheduler()
+	s := newScheduler(builtinStageLayout())
 	s.bindActionInputs(assignments)
 	assert.True(t, s.requiredInputs[mockArtifact].Contains(DeployStage))
 }
@@ -52,7 +55,7 @@ func TestFindOptionalInputOccurances(t *testing.T) {
 	assignments[OnDemand].Add(&Action{Name: "actionA", Stage: OnDemand, OutputArtifacts: []Artifact{mockArtifact}})
 	assignments[CommitStage].Add(&Action{Name: "actionB", Stage: CommitStage, OptionalInputArtifacts: []Artifact{mockArtifact}})
 	assignments[DeployStage].Add(&Action{Name: "actionC", Stage: DeployStage, InputArtifacts: []Artifact{mockArtifact}})
-	s := newScheduler()
+	s := newScheduler(builtinStageLayout())
 	s.bindActionInputs(assignments)
 	assert.True(t, s.optionalInputs[mockArtifact].Contains(CommitStage))
 	assert.True(t, s.requiredInputs[mockArtifact].Contains(DeployStage))
@@ -67,7 +70,7 @@ func TestSchedulerAssignOnDemandActions(t *testing.T) {
 	}
 	assignments[OnDemand].Add(actionA)
 	assert.True(t, assignments[OnDemand].Contains(actionA))
-	s := newScheduler()
+	s := newScheduler(builtinStageLayout())
 	t.Run("requiredInputs", func(t *testing.T) {
 		s.requiredInputs[mockArtifact] = mapset.NewSet[Stage](ReleaseStage, CommitStage)
 		if err := s.assignOnDemandActions(assignments); err != nil {
@@ -96,7 +99,7 @@ func TestSchedulerSortActions(t *testing.T) {
 		assignments := map[Stage]mapset.Set[*Action]{
 			CommitStage: mapset.NewSet[*Action](actionB, actionC, actionA),
 		}
-		s := newScheduler()
+		s := newScheduler(builtinStageLayout())
 		sortedAssignments, err := s.sortActions(assignments)
 		if err != nil {
 			t.Fatal(err)
@@ -113,7 +116,7 @@ func TestSchedulerSortActions(t *testing.T) {
 		assignments := map[Stage]mapset.Set[*Action]{
 			CommitStage: mapset.NewSet[*Action](actionB, actionA),
 		}
-		s := newScheduler()
+		s := newScheduler(builtinStageLayout())
 		_, err := s.sortActions(assignments)
 		assert.ErrorContains(t, err, "the following action has inputs that cannot be resolved: 'actionB'")
 	})
@@ -137,7 +140,7 @@ func TestSchedulerSchedule(t *testing.T) {
 		"actionD": actionD,
 		"actionE": actionE,
 	}
-	s := newScheduler()
+	s := newScheduler(builtinStageLayout())
 	schedule, err := s.schedule([]string{"actionA", "actionB", "actionC", "actionD", "actionE"})
 	if err != nil {
 		t.Fatal(err)
@@ -148,3 +151,32 @@ func TestSchedulerSchedule(t *testing.T) {
 	assert.Equal(t, schedule[ReleaseStage][0], actionB)
 	assert.Equal(t, schedule[ReleaseStage][1], actionE)
 }
+
+func TestSchedulerScheduleCustomStages(t *testing.T) {
+	defer func() {
+		registeredActions = map[string]*Action{}
+	}()
+	var mockArtifactA Artifact = 1
+	actionA := &Action{Name: "actionA", Stage: OnDemand, OutputArtifacts: []Artifact{mockArtifactA}}
+	actionB := &Action{Name: "actionB", Stage: NonFunctionalStage, InputArtifacts: []Artifact{mockArtifactA}}
+	actionC := &Action{Name: "actionC", Stage: DeployStage, InputArtifacts: []Artifact{mockArtifactA}}
+	registeredActions = map[string]*Action{
+		"actionA": actionA,
+		"actionB": actionB,
+		"actionC": actionC,
+	}
+	layout, err := newStageLayout(ConfigStages{
+		Order:   []string{"commit", "deploy", "smoke"},
+		Actions: map[string]string{"actionB": "smoke"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	schedule, err := newScheduler(layout).schedule([]string{"actionA", "actionB", "actionC"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	deploy, smoke := Stage(layout.index("deploy")), Stage(layout.index("smoke"))
+	assert.Equal(t, []*Action{actionA, actionC}, schedule[deploy])
+	assert.Equal(t, []*Action{actionB}, schedule[smoke])
+}
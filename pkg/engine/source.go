@@ -0,0 +1,53 @@
+package engine
+
+import (
+	"context"
+	"os"
+	"path"
+	"path/filepath"
+
+	"dagger.io/dagger"
+)
+
+// FileReader reads a file relative to the source root.
+type FileReader func(name string) ([]byte, error)
+
+// ContainerFileReader returns a reader of the source files mounted in
+// the action container.
+func ContainerFileReader(container *dagger.Container) FileReader {
+	return func(name string) ([]byte, error) {
+		contents, err := container.File(path.Join("/src", name)).Contents(context.Background())
+		return []byte(contents), err
+	}
+}
+
+// HasEntry returns true if the name is in the directory entries.
+func HasEntry(entries []string, name string) bool {
+	for _, entry := range entries {
+		if entry == name {
+			return true
+		}
+	}
+	return false
+}
+
+// AnyFileExists returns true if any of the slash separated paths exist
+// in the source.
+func AnyFileExists(source string, names ...string) (bool, error) {
+	for _, name := range names {
+		if _, err := os.Stat(filepath.Join(source, filepath.FromSlash(name))); os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+// WithImage initializes the action container from the image. The
+// source and cache mounts are kept, and the working directory is reset
+// to the source root.
+func WithImage(container *dagger.Container, name, image string) *dagger.Container {
+	return container.Pipeline(name).From(image).WithWorkdir("/src")
+}
@@ -0,0 +1,39 @@
+package engine
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHasEntry(t *testing.T) {
+	entries := []string{"go.mod", "main.go"}
+	assert.True(t, HasEntry(entries, "main.go"))
+	assert.False(t, HasEntry(entries, "go.sum"))
+}
+
+func TestAnyFileExists(t *testing.T) {
+	d, err := os.MkdirTemp("", "test-source")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(d)
+	if err := os.MkdirAll(filepath.Join(d, "spec"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(d, "spec", "spec_helper.rb"), []byte{}, 0644); err != nil {
+		t.Fatal(err)
+	}
+	ok, err := AnyFileExists(d, ".rspec", "spec/spec_helper.rb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, ok)
+	ok, err = AnyFileExists(d, ".rspec")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.False(t, ok)
+}
@@ -1,5 +1,10 @@
 package engine
 
+import (
+	"fmt"
+	"os"
+)
+
 type Stage int
 
 const (
@@ -23,3 +28,113 @@ var actionStages = []string{
 func GetStage(stage Stage) string {
 	return actionStages[stage]
 }
+
+// stageLayout is the ordered set of stages that actions are scheduled
+// into. The first stage is always the on-demand placeholder so that
+// stage indexes keep their ordering semantics in the scheduler.
+type stageLayout struct {
+	names      []string
+	overrides  map[string]string
+	prerelease map[string]bool
+}
+
+// builtinStageLayout returns the layout of the built-in stages.
+func builtinStageLayout() *stageLayout {
+	return &stageLayout{
+		names:      actionStages,
+		overrides:  map[string]string{},
+		prerelease: map[string]bool{GetStage(ReleaseStage): true},
+	}
+}
+
+// newStageLayout creates the stage layout from the declarative stage
+// configuration, falling back to the built-in stages.
+func newStageLayout(config ConfigStages) (*stageLayout, error) {
+	layout := builtinStageLayout()
+	if len(config.Order) > 0 {
+		layout.names = []string{GetStage(OnDemand)}
+		for _, name := range config.Order {
+			if name == "" {
+				return nil, fmt.Errorf("stage names cannot be empty")
+			}
+			if layout.index(name) >= 0 {
+				return nil, fmt.Errorf("stage '%s' is defined more than once", name)
+			}
+			layout.names = append(layout.names, name)
+		}
+	}
+	for action, name := range config.Actions {
+		if layout.index(name) <= 0 {
+			return nil, fmt.Errorf("action '%s' is mapped to undefined stage '%s'", action, name)
+		}
+		layout.overrides[action] = name
+	}
+	if config.Release != nil {
+		layout.prerelease = map[string]bool{}
+		for _, name := range config.Release {
+			if layout.index(name) <= 0 {
+				return nil, fmt.Errorf("release stage '%s' is not defined", name)
+			}
+			layout.prerelease[name] = true
+		}
+	}
+	return layout, nil
+}
+
+// checkGates returns an error if a gate does not name a stage of the
+// layout, or if an http gate has no token.
+func (l *stageLayout) checkGates(gates map[string]ConfigGate) error {
+	for name, gate := range gates {
+		if l.index(name) <= 0 {
+			return fmt.Errorf("gate '%s' does not name a defined stage", name)
+		}
+		if gate.Method == HTTPGate && os.Getenv(string(GateToken)) == "" {
+			return fmt.Errorf("the http gate for stage '%s' requires the %s input", name, GateToken)
+		}
+	}
+	return nil
+}
+
+func (l *stageLayout) index(name string) int {
+	for i, stage := range l.names {
+		if stage == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// name returns the name of the stage at the layout index.
+func (l *stageLayout) name(stage Stage) string {
+	return l.names[stage]
+}
+
+// stageName returns the name of the stage the action runs in.
+func (l *stageLayout) stageName(action *Action) string {
+	if name, ok := l.overrides[action.Name]; ok {
+		return name
+	}
+	return GetStage(action.Stage)
+}
+
+// stageOf returns the layout index of the stage the action runs in.
+func (l *stageLayout) stageOf(action *Action) (Stage, error) {
+	name := l.stageName(action)
+	i := l.index(name)
+	if i < 0 {
+		return OnDemand, fmt.Errorf("action '%s' is assigned to stage '%s' which is not defined in the stage order", action.Name, name)
+	}
+	return Stage(i), nil
+}
+
+// withoutReleaseStages removes the stages that are skipped for
+// prerelease runs.
+func (l *stageLayout) withoutReleaseStages(stages []string) []string {
+	filtered := []string{}
+	for _, stage := range stages {
+		if !l.prerelease[stage] {
+			filtered = append(filtered, stage)
+		}
+	}
+	return filtered
+}
@@ -9,3 +9,79 @@ import (
 func TestGetStage(t *testing.T) {
 	assert.Equal(t, GetStage(CommitStage), "commit")
 }
+
+func TestNewStageLayout(t *testing.T) {
+	t.Run("builtin", func(t *testing.T) {
+		layout, err := newStageLayout(ConfigStages{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, actionStages, layout.names)
+		stage, err := layout.stageOf(&Action{Name: "actionA", Stage: DeployStage})
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, DeployStage, stage)
+	})
+	t.Run("custom", func(t *testing.T) {
+		layout, err := newStageLayout(ConfigStages{
+			Order:   []string{"commit", "security", "deploy", "smoke", "release"},
+			Actions: map[string]string{"actionA": "security"},
+			Release: []string{"smoke", "release"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		stage, err := layout.stageOf(&Action{Name: "actionA", Stage: NonFunctionalStage})
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, "security", layout.name(stage))
+		stage, err = layout.stageOf(&Action{Name: "actionB", Stage: DeployStage})
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, Stage(3), stage)
+		assert.Equal(t, []string{"commit", "deploy"}, layout.withoutReleaseStages([]string{"commit", "deploy", "smoke", "release"}))
+	})
+	t.Run("undefined action stage", func(t *testing.T) {
+		layout, err := newStageLayout(ConfigStages{Order: []string{"commit"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = layout.stageOf(&Action{Name: "actionA", Stage: DeployStage})
+		assert.ErrorContains(t, err, "action 'actionA' is assigned to stage 'deploy' which is not defined in the stage order")
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := newStageLayout(ConfigStages{Order: []string{"commit", "commit"}})
+		assert.ErrorContains(t, err, "stage 'commit' is defined more than once")
+		_, err = newStageLayout(ConfigStages{Actions: map[string]string{"actionA": "smoke"}})
+		assert.ErrorContains(t, err, "action 'actionA' is mapped to undefined stage 'smoke'")
+		_, err = newStageLayout(ConfigStages{Release: []string{"smoke"}})
+		assert.ErrorContains(t, err, "release stage 'smoke' is not defined")
+	})
+}
+
+func TestWithoutReleaseStages(t *testing.T) {
+	stages := builtinStageLayout().withoutReleaseStages([]string{
+		GetStage(CommitStage),
+		GetStage(DeployStage),
+		GetStage(ReleaseStage),
+	})
+	assert.NotContains(t, stages, GetStage(ReleaseStage))
+	assert.Contains(t, stages, GetStage(DeployStage))
+}
+
+func TestCheckGates(t *testing.T) {
+	layout, err := newStageLayout(ConfigStages{Order: []string{"commit", "smoke", "deploy"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, layout.checkGates(map[string]ConfigGate{"smoke": {}, "deploy": {Method: MarkerGate}}))
+	assert.ErrorContains(t, layout.checkGates(map[string]ConfigGate{"relase": {}}), "gate 'relase' does not name a defined stage")
+	assert.ErrorContains(t, layout.checkGates(map[string]ConfigGate{"": {}}), "gate '' does not name a defined stage")
+	t.Setenv(string(GateToken), "")
+	assert.ErrorContains(t, layout.checkGates(map[string]ConfigGate{"deploy": {Method: HTTPGate}}), "requires the GATE_TOKEN input")
+	t.Setenv(string(GateToken), "secret")
+	assert.NoError(t, layout.checkGates(map[string]ConfigGate{"deploy": {Method: HTTPGate}}))
+}
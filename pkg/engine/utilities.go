@@ -2,6 +2,7 @@ package engine
 
 import (
 	"context"
+	"path/filepath"
 
 	"dagger.io/dagger"
 )
@@ -10,10 +11,16 @@ const (
 	DockerCLIOnDebian = "debian"
 )
 
+// reportsPath is the host directory that action reports are written to.
+const reportsPath = "./trustacks.reports"
+
 type ActionUtilities struct {
 	*ArtifactStore
-	client *dagger.Client
-	config *Config
+	client     *dagger.Client
+	config     *Config
+	prerelease bool
+	facts      []Fact
+	summary    []string
 }
 
 func (util *ActionUtilities) SetSecret(name, plaintext string) *dagger.Secret {
@@ -24,6 +31,35 @@ func (util *ActionUtilities) GetConfig() *Config {
 	return util.config
 }
 
+// AddSummary adds lines to the action summary that is printed after the
+// action completes.
+func (util *ActionUtilities) AddSummary(lines ...string) {
+	util.summary = append(util.summary, lines...)
+}
+
+// ExportReport writes the report directory to the host so that it is
+// available after the run (ie. test traces of failed runs).
+func (util *ActionUtilities) ExportReport(name string, dir *dagger.Directory) error {
+	_, err := dir.Export(context.Background(), filepath.Join(reportsPath, name))
+	return err
+}
+
+// IsPrerelease returns true if the action plan is run as a prerelease.
+func (util *ActionUtilities) IsPrerelease() bool {
+	return util.prerelease
+}
+
+// HasFact returns true if the fact was gathered from the source when the
+// action plan was created.
+func (util *ActionUtilities) HasFact(fact Fact) bool {
+	for _, gathered := range util.facts {
+		if gathered == fact {
+			return true
+		}
+	}
+	return false
+}
+
 func (util *ActionUtilities) WithDockerdService(container *dagger.Container) (*dagger.Container, func(), error) {
 	dockerdPort := 2376
 	dockerClientCerts := util.client.CacheVolume("trustacks-docker-client-certs")
@@ -73,6 +109,12 @@ apt-get install -y docker-ce docker-ce-cli containerd.io docker-buildx-plugin
 	return container
 }
 
-func newActionUtilities(client *dagger.Client, artifacts *ArtifactStore, config *Config) *ActionUtilities {
-	return &ActionUtilities{artifacts, client, config}
+func newActionUtilities(client *dagger.Client, artifacts *ArtifactStore, config *Config, prerelease bool, facts []Fact) *ActionUtilities {
+	return &ActionUtilities{
+		ArtifactStore: artifacts,
+		client:        client,
+		config:        config,
+		prerelease:    prerelease,
+		facts:         facts,
+	}
 }
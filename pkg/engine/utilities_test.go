@@ -18,6 +18,24 @@ func TestGetConfig(t *testing.T) {
 	assert.Equal(t, "1.1.1", utils.GetConfig().Common.Version)
 }
 
+func TestIsPrerelease(t *testing.T) {
+	assert.False(t, (&ActionUtilities{}).IsPrerelease())
+	assert.True(t, (&ActionUtilities{prerelease: true}).IsPrerelease())
+}
+
+func TestHasFact(t *testing.T) {
+	utils := &ActionUtilities{facts: []Fact{Fact(1), Fact(3)}}
+	assert.True(t, utils.HasFact(Fact(3)))
+	assert.False(t, utils.HasFact(Fact(2)))
+}
+
+func TestAddSummary(t *testing.T) {
+	utils := &ActionUtilities{}
+	utils.AddSummary("total: 80.0%")
+	utils.AddSummary("pkg/a: 75.0%", "pkg/b: 85.0%")
+	assert.Equal(t, []string{"total: 80.0%", "pkg/a: 75.0%", "pkg/b: 85.0%"}, utils.summary)
+}
+
 func TestSetSecretIntegration(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test")